@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"strings"
 	"sync"
@@ -30,6 +31,8 @@ type checkInitData struct {
 	workspace *workspace.Workspace
 	client    db_common.Client
 	result    *db_common.InitResult
+	// optional baseline results to compare the results of this run with
+	baseline *controlexecute.Baseline
 }
 
 type exportData struct {
@@ -79,23 +82,32 @@ You may specify one or more benchmarks or controls to run (separated by a space)
 		AddBoolFlag(constants.ArgHeader, "", true, "Include column headers for csv and table output").
 		AddBoolFlag(constants.ArgHelp, "h", false, "Help for check").
 		AddStringFlag(constants.ArgSeparator, "", ",", "Separator string for csv output").
-		AddStringFlag(constants.ArgOutput, "", "text", "Select a console output format: brief, csv, html, json, md, text or none").
+		AddStringFlag(constants.ArgOutput, "", "text", "Select a console output format: brief, csv, html, json, junit, md, sarif, text or none").
 		AddBoolFlag(constants.ArgTimer, "", false, "Turn on the timer which reports check time").
 		AddStringSliceFlag(constants.ArgSearchPath, "", nil, "Set a custom search_path for the steampipe user for a check session (comma-separated)").
 		AddStringSliceFlag(constants.ArgSearchPathPrefix, "", nil, "Set a prefix to the current search path for a check session (comma-separated)").
 		AddStringFlag(constants.ArgTheme, "", "dark", "Set the output theme for 'text' output: light, dark or plain").
-		AddStringSliceFlag(constants.ArgExport, "", nil, "Export output to files in various output formats: csv, html, json or md").
+		AddStringSliceFlag(constants.ArgExport, "", nil, "Export output to files in various output formats: csv, html, json, junit, md, sarif or the name of a template in ~/.steampipe/check/templates").
+		AddStringFlag(constants.ArgBaseline, "", "", "Compare results with a previous run, exported using '--export json'").
+		AddBoolFlag(constants.ArgStore, "", false, "Store the results in the steampipe_check_run and steampipe_check_result tables").
 		AddBoolFlag(constants.ArgProgress, "", true, "Display control execution progress").
 		AddBoolFlag(constants.ArgDryRun, "", false, "Show which controls will be run without running them").
+		AddBoolFlag(constants.ArgWatch, "", false, "Watch the workspace and re-run controls which change (text and brief output only)").
 		AddStringSliceFlag(constants.ArgTag, "", nil, "Filter controls based on their tag values ('--tag key=value')").
 		AddStringSliceFlag(constants.ArgVarFile, "", nil, "Specify an .spvar file containing variable values").
+		AddStringFlag(constants.ArgDatabaseUrl, "", "", "Connect to a remote Steampipe service using a postgres connection string instead of the local service").
+		AddIntFlag(constants.ArgCacheTTL, "", 0, "Set the maximum age (in seconds) of cached results for controls which do not define a 'cache_ttl' - only values shorter than the connection cache TTL take effect").
+		AddIntFlag(constants.ArgQueryTimeout, "", 0, "Set the query timeout (in seconds) for all controls, overriding any control or benchmark 'timeout'").
 		// NOTE: use StringArrayFlag for ArgVariable, not StringSliceFlag
 		// Cobra will interpret values passed to a StringSliceFlag as CSV,
 		// where args passed to StringArrayFlag are not parsed and used raw
 		AddStringArrayFlag(constants.ArgVariable, "", nil, "Specify the value of a variable").
 		AddStringFlag(constants.ArgWhere, "", "", "SQL 'where' clause, or named query, used to filter controls (cannot be used with '--tag')").
+		AddStringFlag(constants.ArgShard, "", "", "Run one of a number of equally sized subsets of the controls, e.g. '2/5' (combine the exported results with 'steampipe check merge')").
 		AddIntFlag(constants.ArgMaxParallel, "", constants.DefaultMaxConnections, "The maximum number of parallel executions", cmdconfig.FlagOptions.Hidden())
 
+	cmd.AddCommand(checkMergeCmd())
+
 	return cmd
 }
 
@@ -125,6 +137,17 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// NOTE: read the watch flag directly rather than from viper,
+	// as the terminal options 'watch' property (used by query) is stored under the same key
+	watch, _ := cmd.Flags().GetBool(constants.ArgWatch)
+	if watch {
+		if outputFormat := viper.GetString(constants.ArgOutput); outputFormat != constants.OutputFormatText && outputFormat != constants.OutputFormatBrief {
+			utils.ShowError(fmt.Errorf("'--%s' is only supported for 'text' and 'brief' output", constants.ArgWatch))
+			exitCode = 2
+			return
+		}
+	}
+
 	var spinner *spinner.Spinner
 	if viper.GetBool(constants.ArgProgress) {
 		spinner = display.ShowSpinner("Starting controls...")
@@ -141,14 +164,17 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 	ctx := initData.ctx
 	workspace := initData.workspace
 	client := initData.client
+	baseline := initData.baseline
 	failures := 0
 	var exportErrors []error
 	exportErrorsLock := sync.Mutex{}
 	exportWaitGroup := sync.WaitGroup{}
 	var durations []time.Duration
+	// the execution tree for each arg, used to re-run the controls in watch mode
+	executionTrees := make([]*controlexecute.ExecutionTree, len(args))
 
 	// treat each arg as a separate execution
-	for _, arg := range args {
+	for i, arg := range args {
 		if utils.IsContextCancelled(ctx) {
 			durations = append(durations, 0)
 			// skip over this arg, since the execution was cancelled
@@ -166,15 +192,27 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 
 		// execute controls synchronously (execute returns the number of failures)
 		failures += executionTree.Execute(ctx, client)
+		// if a baseline was specified, annotate the results with their changes since the baseline run
+		if baseline != nil {
+			executionTree.ApplyBaseline(baseline)
+		}
 		err = displayControlResults(ctx, executionTree)
 		utils.FailOnError(err)
 
+		// store the results in the check history tables, if requested
+		if viper.GetBool(constants.ArgStore) && !viper.GetBool(constants.ArgDryRun) {
+			if err := executionTree.Store(ctx, client, arg, args); err != nil {
+				utils.ShowError(err)
+			}
+		}
+
 		if len(exportFormats) > 0 {
 			d := exportData{executionTree: executionTree, exportFormats: exportFormats, errorsLock: &exportErrorsLock, errors: exportErrors, waitGroup: &exportWaitGroup}
 			exportCheckResult(ctx, &d)
 		}
 
 		durations = append(durations, executionTree.EndTime.Sub(executionTree.StartTime))
+		executionTrees[i] = executionTree
 	}
 
 	// wait for exports to complete
@@ -187,6 +225,11 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		printTiming(args, durations)
 	}
 
+	// in watch mode, re-run changed controls until cancelled
+	if watch {
+		failures = watchCheck(initData, args, executionTrees)
+	}
+
 	// set global exit code
 	exitCode = failures
 }
@@ -198,6 +241,12 @@ func initialiseCheck(spinner *spinner.Spinner) *checkInitData {
 
 	cmdconfig.Viper().Set(constants.ConfigKeyShowInteractiveOutput, false)
 
+	// register any user-defined export templates - failure to load a template is not fatal,
+	// unless the template is used as an export format (in which case the export target fails validation)
+	if err := controldisplay.LoadTemplateFormatters(constants.CheckTemplateDir()); err != nil {
+		log.Printf("[WARN] %s", err.Error())
+	}
+
 	err := validateOutputFormat()
 	if err != nil {
 		initData.result.Error = err
@@ -210,6 +259,31 @@ func initialiseCheck(spinner *spinner.Spinner) *checkInitData {
 		return initData
 	}
 
+	if viper.IsSet(constants.ArgCacheTTL) && viper.GetInt(constants.ArgCacheTTL) <= 0 {
+		initData.result.Error = fmt.Errorf("invalid value for --%s - must be a positive number of seconds", constants.ArgCacheTTL)
+		return initData
+	}
+	if viper.GetInt(constants.ArgQueryTimeout) < 0 {
+		initData.result.Error = fmt.Errorf("invalid value for --%s - must be a positive number of seconds", constants.ArgQueryTimeout)
+		return initData
+	}
+
+	if shard := viper.GetString(constants.ArgShard); shard != "" {
+		if _, err := controlexecute.ParseShard(shard); err != nil {
+			initData.result.Error = err
+			return initData
+		}
+	}
+
+	// load the baseline results, if specified
+	if baselinePath := viper.GetString(constants.ArgBaseline); baselinePath != "" {
+		initData.baseline, err = controlexecute.LoadBaseline(baselinePath)
+		if err != nil {
+			initData.result.Error = err
+			return initData
+		}
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	startCancelHandler(cancel)
 	initData.ctx = ctx
@@ -231,10 +305,13 @@ func initialiseCheck(spinner *spinner.Spinner) *checkInitData {
 	}
 
 	// check if the required plugins are installed
-	err = initData.workspace.CheckRequiredPluginsInstalled()
-	if err != nil {
-		initData.result.Error = err
-		return initData
+	// (plugins for a remote service are installed on the remote host so cannot be verified)
+	if viper.GetString(constants.ArgConnectionString) == "" {
+		err = initData.workspace.CheckRequiredPluginsInstalled()
+		if err != nil {
+			initData.result.Error = err
+			return initData
+		}
 	}
 
 	if len(initData.workspace.Controls) == 0 {
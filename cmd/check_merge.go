@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/cmdconfig"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controldisplay"
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/utils"
+)
+
+// checkMergeCmd :: handler for check merge
+func checkMergeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge [flags] file...",
+		Args:  cobra.ArbitraryArgs,
+		Run:   runCheckMergeCmd,
+		Short: "Merge the results of check runs exported as JSON",
+		Long: `Merge the results of check runs exported as JSON.
+
+Combine the results of one or more check runs, exported using '--export json',
+and display or export them as if they came from a single run. This is typically
+used to combine the results of a check run split into shards with '--shard'.
+
+Examples:
+
+  # Run the controls in 3 shards, then merge the results
+  steampipe check all --shard 1/3 --export shard1.json
+  steampipe check all --shard 2/3 --export shard2.json
+  steampipe check all --shard 3/3 --export shard3.json
+  steampipe check merge shard1.json shard2.json shard3.json --export html`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHeader, "", true, "Include column headers for csv and table output").
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for check merge").
+		AddStringFlag(constants.ArgSeparator, "", ",", "Separator string for csv output").
+		AddStringFlag(constants.ArgOutput, "", "text", "Select a console output format: brief, csv, html, json, junit, md, sarif, text or none").
+		AddStringFlag(constants.ArgTheme, "", "dark", "Set the output theme for 'text' output: light, dark or plain").
+		AddStringSliceFlag(constants.ArgExport, "", nil, "Export output to files in various output formats: csv, html, json, junit, md, sarif or the name of a template in ~/.steampipe/check/templates")
+
+	return cmd
+}
+
+func runCheckMergeCmd(cmd *cobra.Command, args []string) {
+	utils.LogTime("runCheckMergeCmd start")
+	defer func() {
+		utils.LogTime("runCheckMergeCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	// verify we have at least one file to merge
+	if !validateArgs(cmd, args) {
+		return
+	}
+
+	// register any user-defined export templates - failure to load a template is not fatal,
+	// unless the template is used as an export format (in which case the export target fails validation)
+	if err := controldisplay.LoadTemplateFormatters(constants.CheckTemplateDir()); err != nil {
+		log.Printf("[WARN] %s", err.Error())
+	}
+	utils.FailOnError(validateOutputFormat())
+	utils.FailOnError(initialiseColorScheme())
+
+	exportFormats, err := getExportTargets("merge")
+	utils.FailOnError(err)
+
+	executionTree, err := controlexecute.MergeResults(args)
+	if err != nil {
+		utils.ShowError(err)
+		exitCode = 4
+		return
+	}
+
+	ctx := context.Background()
+	err = displayControlResults(ctx, executionTree)
+	utils.FailOnError(err)
+
+	if len(exportFormats) > 0 {
+		if exportErrors := exportControlResults(ctx, executionTree, exportFormats); len(exportErrors) > 0 {
+			utils.ShowError(utils.CombineErrors(exportErrors...))
+		}
+	}
+
+	// as for check, the exit code is the number of failures
+	exitCode = executionTree.Root.Summary.Status.Alarm + executionTree.Root.Summary.Status.Error
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/report/reportevents"
+	"github.com/turbot/steampipe/utils"
+)
+
+// checkWatcher re-runs the controls of a check when the workspace changes
+type checkWatcher struct {
+	initData *checkInitData
+	args     []string
+	// the most recent execution tree for each arg
+	executionTrees []*controlexecute.ExecutionTree
+	// lock to ensure only one re-run executes at a time
+	runLock sync.Mutex
+}
+
+// watchCheck watches the workspace and, whenever controls change, re-runs the new and changed controls
+// the results of unchanged controls are reused, and the results are displayed with a diff against the previous run
+// it blocks until the check is cancelled and returns the number of failures of the most recent run
+func watchCheck(initData *checkInitData, args []string, executionTrees []*controlexecute.ExecutionTree) int {
+	w := &checkWatcher{
+		initData:       initData,
+		args:           args,
+		executionTrees: executionTrees,
+	}
+	initData.workspace.RegisterReportEventHandler(w.handleWorkspaceEvent)
+	if err := initData.workspace.SetupWatcher(initData.client, nil); err != nil {
+		utils.ShowErrorWithMessage(err, "failed to watch workspace")
+		return w.failures()
+	}
+	w.showWatchingMessage()
+
+	<-initData.ctx.Done()
+
+	w.runLock.Lock()
+	defer w.runLock.Unlock()
+	return w.failures()
+}
+
+func (w *checkWatcher) handleWorkspaceEvent(event reportevents.ReportEvent) {
+	controlsChanged, ok := event.(*reportevents.ControlsChanged)
+	if !ok {
+		return
+	}
+	// the workspace is locked while its event handlers are called, so re-run asynchronously
+	go w.rerun(controlsChanged)
+}
+
+func (w *checkWatcher) rerun(controlsChanged *reportevents.ControlsChanged) {
+	w.runLock.Lock()
+	defer w.runLock.Unlock()
+
+	ctx := w.initData.ctx
+	if utils.IsContextCancelled(ctx) {
+		return
+	}
+
+	// build map of the names of the controls to execute - all others reuse their previous results
+	changedControls := make(map[string]bool)
+	for _, c := range controlsChanged.ChangedControls {
+		changedControls[c.Name()] = true
+	}
+	for _, c := range controlsChanged.NewControls {
+		changedControls[c.Name()] = true
+	}
+
+	clearScreen()
+	for i, arg := range w.args {
+		previous := w.executionTrees[i]
+
+		// rebuild the execution tree, as the benchmarks may have changed
+		executionTree, err := controlexecute.NewExecutionTree(ctx, w.initData.workspace, w.initData.client, arg)
+		if err != nil {
+			utils.ShowErrorWithMessage(err, "failed to resolve controls from argument")
+			continue
+		}
+		executionTree.ReusePreviousResults(previous, changedControls)
+		executionTree.Execute(ctx, w.initData.client)
+
+		// show how the results have changed since the previous run
+		if previous != nil {
+			executionTree.ApplyBaseline(controlexecute.NewBaseline(previous.Root))
+		}
+		if err := displayControlResults(ctx, executionTree); err != nil {
+			utils.ShowError(err)
+		}
+		w.executionTrees[i] = executionTree
+	}
+	w.showWatchingMessage()
+}
+
+func (w *checkWatcher) failures() int {
+	failures := 0
+	for _, executionTree := range w.executionTrees {
+		if executionTree != nil {
+			failures += executionTree.Root.Summary.Status.Alarm + executionTree.Root.Summary.Status.Error
+		}
+	}
+	return failures
+}
+
+func (w *checkWatcher) showWatchingMessage() {
+	if viper.GetBool(constants.ArgProgress) {
+		fmt.Println()
+		fmt.Println("Watching for changes... (press Ctrl+C to exit)")
+	}
+}
+
+// clearScreen clears the terminal and moves the cursor to the top left
+func clearScreen() {
+	fmt.Print("\033[H\033[2J")
+}
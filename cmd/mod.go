@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/cmdconfig"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/display"
+	"github.com/turbot/steampipe/mod_installer"
+	"github.com/turbot/steampipe/utils"
+)
+
+// mod management commands
+func modCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "mod [command]",
+		Args:  cobra.NoArgs,
+		Short: "Steampipe mod management",
+		Long: `Steampipe mod management.
+
+Mods are collections of queries, controls and benchmarks. A workspace mod may
+depend on other mods, which are declared in the 'requires' block of its mod.sp
+file and installed into the .steampipe/mods folder of the workspace.
+
+Examples:
+
+  # Create a mod definition in the current workspace
+  steampipe mod init
+
+  # Install a mod dependency
+  steampipe mod install github.com/turbot/steampipe-mod-aws-compliance
+
+  # Install all dependencies of the workspace mod
+  steampipe mod install
+
+  # Update all dependencies to their latest compatible version
+  steampipe mod update
+
+  # List installed mods
+  steampipe mod list
+
+  # Uninstall a mod dependency
+  steampipe mod uninstall github.com/turbot/steampipe-mod-aws-compliance`,
+	}
+
+	cmd.AddCommand(modInstallCmd())
+	cmd.AddCommand(modUpdateCmd())
+	cmd.AddCommand(modListCmd())
+	cmd.AddCommand(modUninstallCmd())
+	cmd.AddCommand(modInitCmd())
+	cmd.Flags().BoolP(constants.ArgHelp, "h", false, "Help for mod")
+
+	return cmd
+}
+
+// install
+func modInstallCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "install [flags] [name[@version]]",
+		Args:  cobra.ArbitraryArgs,
+		Run:   runModInstallCmd,
+		Short: "Install one or more mod dependencies",
+		Long: `Install one or more mod dependencies.
+
+If mod names are specified, they are added to the 'requires' block of the workspace
+mod and installed. If no mod names are specified, all dependencies of the workspace
+mod are installed. The version may be a full version (v1.2), a major version (1),
+a branch name or a local path (file:~/my_mods/aws-core). If no version is specified,
+the latest version is installed.
+
+Examples:
+
+  # Install all dependencies of the workspace mod
+  steampipe mod install
+
+  # Install the latest version of a mod
+  steampipe mod install github.com/turbot/steampipe-mod-aws-compliance
+
+  # Install the latest release of major version 1 of a mod
+  steampipe mod install github.com/turbot/steampipe-mod-aws-compliance@1`,
+	}
+
+	cmdconfig.OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for mod install")
+	return cmd
+}
+
+func runModInstallCmd(cmd *cobra.Command, args []string) {
+	utils.LogTime("runModInstallCmd start")
+	defer func() {
+		utils.LogTime("runModInstallCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	installer := mod_installer.NewModInstaller(viper.GetString(constants.ArgWorkspace))
+	spinner := display.ShowSpinner("Installing mods...")
+	var err error
+	if len(args) == 0 {
+		err = installer.InstallWorkspaceDependencies()
+	} else {
+		err = installer.GetMods(args)
+	}
+	display.StopSpinner(spinner)
+	utils.FailOnError(err)
+
+	fmt.Println(installer.InstallReport())
+}
+
+// update
+func modUpdateCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "update [flags] [name]",
+		Args:  cobra.ArbitraryArgs,
+		Run:   runModUpdateCmd,
+		Short: "Update one or more mod dependencies",
+		Long: `Update one or more mod dependencies.
+
+Update mod dependencies to the latest version which has the same major version as
+the version in the 'requires' block of the workspace mod. If no mod names are
+specified, all dependencies are updated.
+
+Examples:
+
+  # Update all dependencies of the workspace mod
+  steampipe mod update
+
+  # Update a single dependency
+  steampipe mod update github.com/turbot/steampipe-mod-aws-compliance`,
+	}
+
+	cmdconfig.OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for mod update")
+	return cmd
+}
+
+func runModUpdateCmd(cmd *cobra.Command, args []string) {
+	utils.LogTime("runModUpdateCmd start")
+	defer func() {
+		utils.LogTime("runModUpdateCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	installer := mod_installer.NewModInstaller(viper.GetString(constants.ArgWorkspace))
+	spinner := display.ShowSpinner("Updating mods...")
+	err := installer.UpdateMods(args)
+	display.StopSpinner(spinner)
+	utils.FailOnError(err)
+
+	fmt.Println(installer.InstallReport())
+}
+
+// list
+func modListCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "list",
+		Args:  cobra.NoArgs,
+		Run:   runModListCmd,
+		Short: "List currently installed mods",
+		Long: `List currently installed mods.
+
+List all mods installed in the workspace mod folder.
+
+Examples:
+
+  # List installed mods
+  steampipe mod list`,
+	}
+
+	cmdconfig.OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for mod list")
+	return cmd
+}
+
+func runModListCmd(cmd *cobra.Command, _ []string) {
+	utils.LogTime("runModListCmd start")
+	defer func() {
+		utils.LogTime("runModListCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	installer := mod_installer.NewModInstaller(viper.GetString(constants.ArgWorkspace))
+	mods, err := installer.ListInstalledMods()
+	utils.FailOnError(err)
+
+	headers := []string{"Name", "Version", "Path"}
+	var rows [][]string
+	for _, mod := range mods {
+		rows = append(rows, []string{mod.Name, fmt.Sprintf("v%s", mod.Version.Original()), mod.Path})
+	}
+	display.ShowWrappedTable(headers, rows, false)
+}
+
+// uninstall
+func modUninstallCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "uninstall [flags] name",
+		Args:  cobra.ArbitraryArgs,
+		Run:   runModUninstallCmd,
+		Short: "Uninstall one or more mod dependencies",
+		Long: `Uninstall one or more mod dependencies.
+
+Remove the mods from the 'requires' block of the workspace mod and delete all
+installed versions of them.
+
+Example:
+
+  # Uninstall a mod dependency
+  steampipe mod uninstall github.com/turbot/steampipe-mod-aws-compliance`,
+	}
+
+	cmdconfig.OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for mod uninstall")
+	return cmd
+}
+
+func runModUninstallCmd(cmd *cobra.Command, args []string) {
+	utils.LogTime("runModUninstallCmd start")
+	defer func() {
+		utils.LogTime("runModUninstallCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	if len(args) == 0 {
+		fmt.Println()
+		utils.ShowError(fmt.Errorf("you need to provide at least one mod to uninstall"))
+		fmt.Println()
+		cmd.Help()
+		fmt.Println()
+		exitCode = 2
+		return
+	}
+
+	installer := mod_installer.NewModInstaller(viper.GetString(constants.ArgWorkspace))
+	utils.FailOnError(installer.UninstallMods(args))
+
+	fmt.Printf("\nUninstalled %d %s\n", len(args), utils.Pluralize("mod", len(args)))
+}
+
+// init
+func modInitCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "init",
+		Args:  cobra.NoArgs,
+		Run:   runModInitCmd,
+		Short: "Initialize the current directory with a mod.sp file",
+		Long: `Initialize the current directory with a mod.sp file.
+
+Example:
+
+  # Create a mod.sp file in the current workspace
+  steampipe mod init`,
+	}
+
+	cmdconfig.OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for mod init")
+	return cmd
+}
+
+func runModInitCmd(cmd *cobra.Command, _ []string) {
+	utils.LogTime("runModInitCmd start")
+	defer func() {
+		utils.LogTime("runModInitCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	workspacePath := viper.GetString(constants.ArgWorkspace)
+	created, err := mod_installer.InitWorkspaceMod(workspacePath)
+	utils.FailOnError(err)
+	if !created {
+		fmt.Printf("A mod definition already exists in %s\n", workspacePath)
+		return
+	}
+	fmt.Printf("Created mod definition in %s\n", workspacePath)
+}
@@ -11,11 +11,15 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/turbot/steampipe-plugin-sdk/logging"
 	"github.com/turbot/steampipe/cmdconfig"
 	"github.com/turbot/steampipe/connection_watcher"
 	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/db/db_local"
+	"github.com/turbot/steampipe/metrics_server"
 	"github.com/turbot/steampipe/plugin_manager"
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
 	"github.com/turbot/steampipe/steampipeconfig"
 	"github.com/turbot/steampipe/utils"
 )
@@ -44,7 +48,22 @@ func runPluginManagerCmd(cmd *cobra.Command, args []string) {
 	configMap := connection_watcher.NewConnectionConfigMap(steampipeConfig.Connections)
 	log.Printf("[TRACE] loaded config map")
 
-	pluginManager := plugin_manager.NewPluginManager(configMap, logger)
+	// plugin processes idle for longer than the idle timeout are stopped (zero means never)
+	idleTimeout := time.Duration(viper.GetInt(constants.ArgPluginIdleTimeout)) * time.Second
+	pluginManager := plugin_manager.NewPluginManager(configMap, idleTimeout, logger)
+	// idle plugins are only stopped when no database session is open
+	pluginManager.SetOpenSessionsFunc(func() (int64, error) {
+		stats, err := db_local.GetServiceStats()
+		if err != nil {
+			return 0, err
+		}
+		return stats.Sessions, nil
+	})
+
+	// reattach to any plugin processes started by a previous instance of the plugin manager
+	if err := pluginManager.RestorePluginsState(); err != nil {
+		log.Printf("[WARN] failed to restore plugin manager plugins state: %s", err.Error())
+	}
 
 	if runConnectionWatcher() {
 		connectionWatcher, err := connection_watcher.NewConnectionWatcher(pluginManager.SetConnectionConfigMap)
@@ -58,10 +77,41 @@ func runPluginManagerCmd(cmd *cobra.Command, args []string) {
 		defer connectionWatcher.Close()
 	}
 
+	// periodically refresh the schema of connections with a schema refresh interval
+	schemaRefresher := connection_watcher.NewSchemaRefresher()
+	schemaRefresher.Start()
+	defer schemaRefresher.Close()
+
+	// if the service was started with a metrics port, serve metrics for as long as the plugin manager runs
+	if metricsServer := startMetricsServer(pluginManager); metricsServer != nil {
+		defer metricsServer.Close()
+	}
+
 	log.Printf("[TRACE] about to serve")
 	pluginManager.Serve()
 }
 
+// startMetricsServer starts the metrics server if a metrics port is set in the service running info
+// failure to start the metrics server is logged, but does not stop the plugin manager
+func startMetricsServer(pluginManager *plugin_manager.PluginManager) *metrics_server.MetricsServer {
+	info, err := db_local.GetStatus()
+	if err != nil || info == nil || info.MetricsPort == 0 {
+		return nil
+	}
+	address := fmt.Sprintf(":%d", info.MetricsPort)
+	if info.ListenType == db_local.ListenTypeLocal {
+		address = fmt.Sprintf("localhost:%d", info.MetricsPort)
+	}
+	metricsServer := metrics_server.NewMetricsServer(address, func() (*pb.ListResponse, error) {
+		return pluginManager.List(&pb.ListRequest{})
+	})
+	if err := metricsServer.Start(); err != nil {
+		log.Printf("[WARN] failed to start metrics server: %s", err.Error())
+		return nil
+	}
+	return metricsServer
+}
+
 func runConnectionWatcher() bool {
 	// if CacheEnabledEnvVar is set, overwrite the value in DefaultConnectionOptions
 	if envStr, ok := os.LookupEnv(constants.EnvConnectionWatcher); ok {
@@ -44,6 +44,9 @@ Examples:
   # List installed plugins
   steampipe plugin list
 
+  # List running plugin processes
+  steampipe plugin ps
+
   # Uninstall a plugin
   steampipe plugin uninstall aws`,
 	}
@@ -52,6 +55,8 @@ Examples:
 	cmd.AddCommand(pluginListCmd())
 	cmd.AddCommand(pluginUninstallCmd())
 	cmd.AddCommand(pluginUpdateCmd())
+	cmd.AddCommand(pluginPsCmd())
+	cmd.AddCommand(pluginRestartCmd())
 	cmd.Flags().BoolP(constants.ArgHelp, "h", false, "Help for plugin")
 
 	return cmd
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/cmdconfig"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/display"
+	"github.com/turbot/steampipe/plugin_manager"
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
+	"github.com/turbot/steampipe/steampipeconfig"
+	"github.com/turbot/steampipe/utils"
+)
+
+// List running plugin processes
+func pluginPsCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "ps",
+		Args:  cobra.NoArgs,
+		Run:   runPluginPsCmd,
+		Short: "List running plugin processes",
+		Long: `List running plugin processes.
+
+List the plugin processes started by the plugin manager, showing the connection
+each process serves, its pid, when it was started and a hash of the connection
+config it was started with.
+
+Examples:
+
+  # List running plugin processes
+  steampipe plugin ps`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for plugin ps")
+	return cmd
+}
+
+// Restart the plugin process for a connection
+func pluginRestartCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "restart [flags] connection",
+		Args:  cobra.ArbitraryArgs,
+		Run:   runPluginRestartCmd,
+		Short: "Restart the plugin process for a connection",
+		Long: `Restart the plugin process for a connection.
+
+Kill the plugin process serving the given connection and start a new one.
+This may be used to recover a plugin process which is not responding.
+
+Database sessions which have already queried the connection continue to use the
+killed plugin process, so their next query against the connection fails - they
+must reconnect to use the new plugin process.
+
+Examples:
+
+  # Restart the plugin process for the aws connection
+  steampipe plugin restart aws`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for plugin restart")
+	return cmd
+}
+
+func runPluginPsCmd(*cobra.Command, []string) {
+	utils.LogTime("runPluginPsCmd start")
+	defer func() {
+		utils.LogTime("runPluginPsCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	pluginManager, err := plugin_manager.GetRunningPluginManager()
+	if err != nil {
+		utils.ShowErrorWithMessage(err, "Plugin listing failed")
+		exitCode = 4
+		return
+	}
+
+	var runningPlugins []*pb.RunningPlugin
+	// if the plugin manager is not running, there are no plugin processes
+	if pluginManager != nil {
+		res, err := pluginManager.List(&pb.ListRequest{})
+		if err != nil {
+			utils.ShowErrorWithMessage(err, "Plugin listing failed")
+			exitCode = 4
+			return
+		}
+		runningPlugins = res.Plugins
+	}
+
+	headers := []string{"Connection", "Plugin", "PID", "Started", "Last Used", "Config Hash"}
+	display.ShowWrappedTable(headers, pluginPsRows(runningPlugins), false)
+}
+
+// pluginPsRows builds the 'plugin ps' table rows for the running plugins
+func pluginPsRows(runningPlugins []*pb.RunningPlugin) [][]string {
+	rows := [][]string{}
+	for _, p := range runningPlugins {
+		rows = append(rows, []string{
+			p.Connection,
+			p.Plugin,
+			strconv.FormatInt(p.Pid, 10),
+			time.Unix(p.StartTime, 0).Format(time.RFC3339),
+			time.Unix(p.LastUsed, 0).Format(time.RFC3339),
+			p.ConfigHash,
+		})
+	}
+	return rows
+}
+
+func runPluginRestartCmd(cmd *cobra.Command, args []string) {
+	utils.LogTime("runPluginRestartCmd start")
+	defer func() {
+		utils.LogTime("runPluginRestartCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	if len(args) != 1 {
+		fmt.Println()
+		utils.ShowError(fmt.Errorf("you need to provide a single connection to restart"))
+		fmt.Println()
+		cmd.Help()
+		fmt.Println()
+		exitCode = 2
+		return
+	}
+	connection := args[0]
+	if _, ok := steampipeconfig.GlobalConfig.Connections[connection]; !ok {
+		utils.ShowError(fmt.Errorf("connection '%s' does not exist", connection))
+		exitCode = 2
+		return
+	}
+
+	pluginManager, err := plugin_manager.GetRunningPluginManager()
+	if err != nil {
+		utils.ShowErrorWithMessage(err, fmt.Sprintf("Failed to restart plugin for connection '%s'", connection))
+		exitCode = 4
+		return
+	}
+	if pluginManager == nil {
+		utils.ShowError(fmt.Errorf("the plugin manager is not running - start the Steampipe service to start plugin processes"))
+		exitCode = 4
+		return
+	}
+
+	res, err := pluginManager.Restart(&pb.RestartRequest{Connection: connection})
+	if err != nil {
+		utils.ShowErrorWithMessage(err, fmt.Sprintf("Failed to restart plugin for connection '%s'", connection))
+		exitCode = 4
+		return
+	}
+	fmt.Printf("Restarted plugin for connection '%s', pid %d\n", connection, res.Reattach.Pid)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
+	"github.com/turbot/steampipe/steampipeconfig"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func TestPluginPsRows(t *testing.T) {
+	started := time.Date(2021, 11, 1, 10, 0, 0, 0, time.Local)
+	lastUsed := started.Add(time.Hour)
+	plugins := []*pb.RunningPlugin{
+		{
+			Connection: "aws",
+			Plugin:     "hub.steampipe.io/plugins/turbot/aws@latest",
+			Pid:        1234,
+			StartTime:  started.Unix(),
+			LastUsed:   lastUsed.Unix(),
+			ConfigHash: "abc123",
+		},
+	}
+	expected := [][]string{
+		{"aws", "hub.steampipe.io/plugins/turbot/aws@latest", "1234", started.Format(time.RFC3339), lastUsed.Format(time.RFC3339), "abc123"},
+	}
+	if rows := pluginPsRows(plugins); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected rows %v, got %v", expected, rows)
+	}
+	if rows := pluginPsRows(nil); len(rows) != 0 {
+		t.Errorf("expected no rows when no plugins are running, got %v", rows)
+	}
+}
+
+type pluginRestartArgsTest struct {
+	args     []string
+	expected int
+}
+
+var testCasesPluginRestartArgs = map[string]pluginRestartArgsTest{
+	"no connection": {
+		args:     []string{},
+		expected: 2,
+	},
+	"multiple connections": {
+		args:     []string{"aws", "gcp"},
+		expected: 2,
+	},
+	"unknown connection": {
+		args:     []string{"gcp"},
+		expected: 2,
+	},
+}
+
+func TestRunPluginRestartCmdArgs(t *testing.T) {
+	globalConfig := steampipeconfig.GlobalConfig
+	defer func() {
+		steampipeconfig.GlobalConfig = globalConfig
+		exitCode = 0
+	}()
+	steampipeconfig.GlobalConfig = &steampipeconfig.SteampipeConfig{
+		Connections: map[string]*modconfig.Connection{"aws": {Name: "aws"}},
+	}
+
+	for name, test := range testCasesPluginRestartArgs {
+		exitCode = 0
+		runPluginRestartCmd(pluginRestartCmd(), test.args)
+		if exitCode != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected exit code %d, got %d", name, test.expected, exitCode)
+		}
+	}
+}
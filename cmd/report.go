@@ -2,13 +2,19 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/logging"
 	"github.com/turbot/steampipe/cmdconfig"
 	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/db/db_local"
+	"github.com/turbot/steampipe/display"
+	"github.com/turbot/steampipe/executionlayer"
+	"github.com/turbot/steampipe/report/reportexport"
 	"github.com/turbot/steampipe/report/reportserver"
 	"github.com/turbot/steampipe/utils"
 )
@@ -19,12 +25,37 @@ func reportCmd() *cobra.Command {
 		TraverseChildren: true,
 		Args:             cobra.ArbitraryArgs,
 		Run:              runReportCmd,
-		Short:            "Run a report",
-		Long:             `Run a report...TODO better description!`,
+		Short:            "Run the report server, or export a report",
+		Long: `Run the report server, or export a report.
+
+Start a server which serves the reports of the current workspace. Reports are
+available from a browser, a websocket (/ws) and a REST API:
+
+  GET  /api/reports                 list the reports in the workspace
+  GET  /api/reports/<name>          get the most recent execution of a report
+  POST /api/reports/<name>/execute  re-execute a report
+
+Examples:
+
+  # Start the report server on the default port
+  steampipe report
+
+  # Start the report server on port 8080, accepting connections from localhost only
+  steampipe report --report-port 8080 --report-listen local
+
+If a report name and --export are passed, the report is executed without starting
+the server, and the results are written to the export files. The format (html or
+json) is inferred from the file extension, or may be given as 'format:file'.
+
+  # Execute a report and export the results as html and json
+  steampipe report report.cis_v140 --export report.html --export report.json`,
 	}
 
 	cmdconfig.OnCmd(cmd).
-		AddBoolFlag(constants.ArgHelp, "h", false, "Help for report")
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for report").
+		AddIntFlag(constants.ArgReportPort, "", constants.ReportServerDefaultPort, "Report server port.").
+		AddStringFlag(constants.ArgReportListen, "", string(reportserver.ListenTypeNetwork), "Accept connections from: local (localhost only) or network (open)").
+		AddStringSliceFlag(constants.ArgExport, "", nil, "Execute the report and export the results to files: html or json")
 	return cmd
 }
 
@@ -34,26 +65,101 @@ func runReportCmd(cmd *cobra.Command, args []string) {
 		logging.LogTime("runReportCmd end")
 		if r := recover(); r != nil {
 			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
 		}
 	}()
 
 	cmdconfig.Viper().Set(constants.ConfigKeyShowInteractiveOutput, false)
 
+	// if export targets are specified, execute the report headless
+	if exports := viper.GetStringSlice(constants.ArgExport); len(exports) > 0 {
+		exitCode = exportReport(cmd, args, exports)
+		return
+	}
+
+	// validate the listen type
+	listen := reportserver.ListenType(viper.GetString(constants.ArgReportListen))
+	utils.FailOnError(listen.IsValid())
+
+	// the server shuts down when this context is cancelled
 	ctx, cancel := context.WithCancel(context.Background())
 	startCancelHandler(cancel)
 
-	// start db if necessary
-	//err := db_local.EnsureDbAndStartService(constants.InvokerReport, true)
-	//utils.FailOnErrorWithMessage(err, "failed to start service")
-	//defer db_local.ShutdownService(constants.InvokerReport)
-
 	server, err := reportserver.NewServer(ctx)
+	utils.FailOnError(err)
+
+	defer server.Shutdown()
+
+	utils.FailOnError(server.Start())
+}
+
+// exportReport executes the report to completion and writes the results to the export targets
+// it returns the exit code for the command
+func exportReport(cmd *cobra.Command, args []string, exports []string) int {
+	if len(args) != 1 {
+		fmt.Println()
+		utils.ShowError(fmt.Errorf("you must provide a single report to export"))
+		fmt.Println()
+		cmd.Help()
+		fmt.Println()
+		return 2
+	}
+	reportName := args[0]
 
+	var targets []*reportexport.ExportTarget
+	for _, export := range exports {
+		target, err := reportexport.NewExportTarget(export)
+		utils.FailOnError(err)
+		targets = append(targets, target)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	startCancelHandler(cancel)
+
+	spinner := display.ShowSpinner("Loading workspace...")
+	w, err := loadWorkspacePromptingForVariables(ctx, spinner)
+	if err != nil {
+		display.StopSpinner(spinner)
+		utils.FailOnErrorWithMessage(err, "failed to load workspace")
+	}
+	defer w.Close()
+
+	display.UpdateSpinnerMessage(spinner, "Connecting to service...")
+	client, err := db_local.GetLocalClient(constants.InvokerReport)
 	if err != nil {
+		display.StopSpinner(spinner)
 		utils.FailOnError(err)
 	}
+	defer client.Close()
 
-	defer server.Shutdown()
+	refreshResult := client.RefreshConnectionAndSearchPaths()
+	if refreshResult.Error != nil {
+		display.StopSpinner(spinner)
+		utils.FailOnError(refreshResult.Error)
+	}
+
+	display.UpdateSpinnerMessage(spinner, fmt.Sprintf("Executing %s...", reportName))
+	root, executeErr := executionlayer.ExecuteReportNodeSync(ctx, reportName, w, client)
+	display.StopSpinner(spinner)
+	refreshResult.ShowWarnings()
+	// if the report could not be created, there is nothing to export
+	if root == nil {
+		utils.FailOnError(executeErr)
+	}
 
-	server.Start()
+	// export even if execution failed - the error is included in the export
+	exitCode := 0
+	for _, target := range targets {
+		if err := target.Export(root); err != nil {
+			utils.ShowErrorWithMessage(err, fmt.Sprintf("failed to export %s", target.File))
+			exitCode = 1
+			continue
+		}
+		fmt.Printf("Exported %s\n", target.File)
+	}
+	if executeErr != nil {
+		utils.ShowError(executeErr)
+		exitCode = 1
+	}
+	return exitCode
 }
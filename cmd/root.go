@@ -144,8 +144,10 @@ func AddCommands() {
 	// explicitly initialise commands here rather than in init functions to allow us to handle errors from the config load
 	rootCmd.AddCommand(
 		pluginCmd(),
+		modCmd(),
 		queryCmd(),
 		checkCmd(),
+		reportCmd(),
 		serviceCmd(),
 		generateCompletionScriptsCmd(),
 		daemonCmd(),
@@ -36,6 +36,7 @@ connection from any Postgres compatible database client.`,
 	cmd.AddCommand(serviceStatusCmd())
 	cmd.AddCommand(serviceStopCmd())
 	cmd.AddCommand(serviceRestartCmd())
+	cmd.AddCommand(serviceUserCmd())
 	cmd.Flags().BoolP(constants.ArgHelp, "h", false, "Help for service")
 	return cmd
 }
@@ -61,6 +62,7 @@ connection from any Postgres compatible database client.`,
 		// for now default listen address to empty so we fall back to the default of the deprecated arg
 		AddStringFlag(constants.ArgListenAddress, "", string(db_local.ListenTypeNetwork), "Accept connections from: local (localhost only) or network (open)").
 		AddStringFlag(constants.ArgServicePassword, "", "", "Set the database password for this session").
+		AddIntFlag(constants.ArgMetricsPort, "", 0, "Serve Prometheus metrics and a health check on this port (disabled if not set)").
 		// foreground enables the service to run in the foreground - till exit
 		AddBoolFlag(constants.ArgForeground, "", false, "Run the service in the foreground").
 		// Hidden flags for internal use
@@ -150,6 +152,11 @@ func runServiceStartCmd(cmd *cobra.Command, args []string) {
 	invoker := constants.Invoker(cmdconfig.Viper().GetString(constants.ArgInvoker))
 	utils.FailOnError(invoker.IsValid())
 
+	metricsPort := viper.GetInt(constants.ArgMetricsPort)
+	if metricsPort < 0 || metricsPort > 65535 || metricsPort == port {
+		panic("Invalid Metrics Port :: MUST be within range (1:65535) and different to the database port")
+	}
+
 	err := db_local.EnsureDBInstalled()
 	utils.FailOnError(err)
 
@@ -169,6 +176,9 @@ func runServiceStartCmd(cmd *cobra.Command, args []string) {
 		if listen != info.ListenType {
 			utils.FailOnError(fmt.Errorf("service is already running and listening on %s - cannot change listen type while it's running", info.ListenType))
 		}
+		if metricsPort != info.MetricsPort {
+			utils.FailOnError(fmt.Errorf("service is already running - cannot change metrics port while it's running"))
+		}
 
 		// convert
 		info.Invoker = constants.InvokerService
@@ -178,7 +188,7 @@ func runServiceStartCmd(cmd *cobra.Command, args []string) {
 		}
 	} else {
 		// start db, refreshing connections
-		status, err := db_local.StartDB(port, listen, invoker)
+		status, err := db_local.StartDB(port, listen, invoker, metricsPort)
 		utils.FailOnError(err)
 
 		if status == db_local.ServiceFailedToStart {
@@ -296,7 +306,7 @@ to force a restart.
 	viper.Set(constants.ArgServicePassword, currentServiceStatus.Password)
 
 	// start db, refreshing connections
-	status, err := db_local.StartDB(currentServiceStatus.Port, currentServiceStatus.ListenType, currentServiceStatus.Invoker)
+	status, err := db_local.StartDB(currentServiceStatus.Port, currentServiceStatus.ListenType, currentServiceStatus.Invoker, currentServiceStatus.MetricsPort)
 	if err != nil {
 		utils.ShowError(err)
 		return
@@ -504,6 +514,7 @@ Steampipe service is running:
   Database: %v
   User:     %v
   Password: %v
+  SSL:      %v%v
 
 Connection string:
 
@@ -520,7 +531,7 @@ Managing the Steampipe service:
   # Stop the service
   steampipe service stop
 `
-		statusMessage = fmt.Sprintf(msg, strings.Join(info.Listen, ", "), info.Port, info.Database, info.User, info.Password, info.User, info.Password, info.Listen[0], info.Port, info.Database)
+		statusMessage = fmt.Sprintf(msg, strings.Join(info.Listen, ", "), info.Port, info.Database, info.User, info.Password, info.SslDescription(), metricsStatus(info), info.User, info.Password, info.Listen[0], info.Port, info.Database)
 	} else {
 		msg := `
 Steampipe service was started for an active %s session. The service will exit when all active sessions exit.
@@ -539,6 +550,14 @@ To keep the service running after the %s session completes, use %s.
 	fmt.Println(statusMessage)
 }
 
+// metricsStatus returns the status line for the metrics endpoint, or an empty string if metrics are disabled
+func metricsStatus(info *db_local.RunningDBInstanceInfo) string {
+	if info.MetricsPort == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n  Metrics:  http://%s:%d/metrics", info.Listen[0], info.MetricsPort)
+}
+
 func printRunningImplicit(invoker constants.Invoker) {
 	fmt.Printf(`
 Steampipe service is running exclusively for an active %s session.
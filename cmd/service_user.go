@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bgentry/speakeasy"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/cmdconfig"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/db/db_local"
+	"github.com/turbot/steampipe/display"
+	"github.com/turbot/steampipe/utils"
+)
+
+// serviceUserCmd :: service user management commands
+func serviceUserCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "user [command]",
+		Args:  cobra.NoArgs,
+		Short: "Steampipe service user management",
+		Long: `Steampipe service user management.
+
+Add named database users to the Steampipe service. Service users have the
+same permissions as the steampipe user, but have their own password and may
+be restricted to connecting from a set of address ranges.`,
+	}
+
+	cmd.AddCommand(serviceUserAddCmd())
+	cmd.AddCommand(serviceUserRemoveCmd())
+	cmd.AddCommand(serviceUserListCmd())
+	cmd.Flags().BoolP(constants.ArgHelp, "h", false, "Help for service user")
+	return cmd
+}
+
+// serviceUserAddCmd :: handler for service user add
+func serviceUserAddCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "add [flags] name",
+		Args:  cobra.ArbitraryArgs,
+		Run:   runServiceUserAddCmd,
+		Short: "Add a service user",
+		Long: `Add a service user.
+
+Create a database user with the same permissions as the steampipe user.
+
+If --password is set, the password is prompted for (or read from stdin if
+stdin is not a terminal). Otherwise a password is generated and displayed
+once. The password is not stored by Steampipe.
+
+The service must be running.
+
+Examples:
+
+  # Add a user who may connect from any address
+  steampipe service user add alice
+
+  # Add a user who may only connect from the 10.0.0.0/8 network
+  steampipe service user add bob --cidr 10.0.0.0/8
+
+  # Add a user with a password read from a file
+  steampipe service user add carol --password < password.txt`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for service user add").
+		AddBoolFlag(constants.ArgPassword, "", false, "Prompt for the password of the user, or read it from stdin (generated if not set)").
+		AddStringSliceFlag(constants.ArgCidr, "", nil, "Address range (CIDR) the user may connect from - may be repeated (default: any address)")
+
+	return cmd
+}
+
+// serviceUserRemoveCmd :: handler for service user remove
+func serviceUserRemoveCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "remove [flags] name",
+		Args:  cobra.ArbitraryArgs,
+		Run:   runServiceUserRemoveCmd,
+		Short: "Remove a service user",
+		Long: `Remove a service user.
+
+Drop the database user. Any tables the user created are reassigned to the
+root user. The service must be running.
+
+Examples:
+
+  # Remove the user alice
+  steampipe service user remove alice`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for service user remove")
+
+	return cmd
+}
+
+// serviceUserListCmd :: handler for service user list
+func serviceUserListCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "list",
+		Args:  cobra.NoArgs,
+		Run:   runServiceUserListCmd,
+		Short: "List service users",
+		Long:  `List the service users and the address ranges they may connect from.`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for service user list")
+
+	return cmd
+}
+
+func runServiceUserAddCmd(cmd *cobra.Command, args []string) {
+	utils.LogTime("runServiceUserAddCmd start")
+	defer func() {
+		utils.LogTime("runServiceUserAddCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	if len(args) != 1 {
+		fmt.Println()
+		utils.ShowError(fmt.Errorf("you need to provide the name of the user to add"))
+		fmt.Println()
+		cmd.Help()
+		fmt.Println()
+		exitCode = 2
+		return
+	}
+	name := args[0]
+
+	var password string
+	if viper.GetBool(constants.ArgPassword) {
+		var err error
+		password, err = readServiceUserPassword()
+		if err != nil {
+			utils.ShowErrorWithMessage(err, "Failed to read password")
+			exitCode = 2
+			return
+		}
+	}
+
+	password, err := db_local.AddServiceUser(name, password, viper.GetStringSlice(constants.ArgCidr))
+	if err != nil {
+		utils.ShowErrorWithMessage(err, fmt.Sprintf("Failed to add service user '%s'", name))
+		exitCode = 4
+		return
+	}
+	fmt.Printf("Added service user '%s'\n", name)
+	if !viper.GetBool(constants.ArgPassword) {
+		fmt.Printf("Password: %s\n", password)
+	}
+}
+
+// readServiceUserPassword prompts for the password of a service user, or reads it from stdin if stdin is not a terminal
+// (the password is not accepted as a flag value, as it would be visible in the process list and shell history)
+func readServiceUserPassword() (string, error) {
+	if isatty.IsTerminal(os.Stdin.Fd()) {
+		password, err := speakeasy.Ask("Password: ")
+		if err != nil {
+			return "", err
+		}
+		return validatePassword(password)
+	}
+	return readPasswordLine(os.Stdin)
+}
+
+// readPasswordLine reads a password from the first line of the reader
+func readPasswordLine(reader io.Reader) (string, error) {
+	line, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return validatePassword(strings.TrimRight(line, "\r\n"))
+}
+
+func validatePassword(password string) (string, error) {
+	if password == "" {
+		return "", fmt.Errorf("the password must not be empty")
+	}
+	return password, nil
+}
+
+func runServiceUserRemoveCmd(cmd *cobra.Command, args []string) {
+	utils.LogTime("runServiceUserRemoveCmd start")
+	defer func() {
+		utils.LogTime("runServiceUserRemoveCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	if len(args) != 1 {
+		fmt.Println()
+		utils.ShowError(fmt.Errorf("you need to provide the name of the user to remove"))
+		fmt.Println()
+		cmd.Help()
+		fmt.Println()
+		exitCode = 2
+		return
+	}
+	name := args[0]
+
+	if err := db_local.RemoveServiceUser(name); err != nil {
+		utils.ShowErrorWithMessage(err, fmt.Sprintf("Failed to remove service user '%s'", name))
+		exitCode = 4
+		return
+	}
+	fmt.Printf("Removed service user '%s'\n", name)
+}
+
+func runServiceUserListCmd(cmd *cobra.Command, args []string) {
+	utils.LogTime("runServiceUserListCmd start")
+	defer func() {
+		utils.LogTime("runServiceUserListCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(helpers.ToError(r))
+			exitCode = 1
+		}
+	}()
+
+	users, err := db_local.ListServiceUsers()
+	if err != nil {
+		utils.ShowErrorWithMessage(err, "Failed to list service users")
+		exitCode = 4
+		return
+	}
+
+	headers := []string{"User", "Allowed Addresses"}
+	rows := [][]string{}
+	for _, user := range users {
+		cidrs := "any"
+		if len(user.Cidrs) > 0 {
+			cidrs = strings.Join(user.Cidrs, ", ")
+		}
+		rows = append(rows, []string{user.Name, cidrs})
+	}
+	display.ShowWrappedTable(headers, rows, false)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+type readPasswordLineTest struct {
+	input       string
+	expected    string
+	expectError bool
+}
+
+var testCasesReadPasswordLine = map[string]readPasswordLineTest{
+	"password with newline": {
+		input:    "s3cret\n",
+		expected: "s3cret",
+	},
+	"password with crlf": {
+		input:    "s3cret\r\n",
+		expected: "s3cret",
+	},
+	"password without newline": {
+		input:    "s3cret",
+		expected: "s3cret",
+	},
+	"only first line is read": {
+		input:    "s3cret\nother\n",
+		expected: "s3cret",
+	},
+	"spaces are preserved": {
+		input:    " s3 cret \n",
+		expected: " s3 cret ",
+	},
+	"empty input": {
+		input:       "",
+		expectError: true,
+	},
+	"empty line": {
+		input:       "\n",
+		expectError: true,
+	},
+}
+
+func TestReadPasswordLine(t *testing.T) {
+	for name, test := range testCasesReadPasswordLine {
+		password, err := readPasswordLine(strings.NewReader(test.input))
+		if test.expectError {
+			if err == nil {
+				t.Errorf("Test: '%s'' FAILED : expected error but did not get one", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+			continue
+		}
+		if password != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %q, got %q", name, test.expected, password)
+		}
+	}
+}
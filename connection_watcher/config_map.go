@@ -12,7 +12,27 @@ func NewConnectionConfigMap(connectionMap map[string]*modconfig.Connection) map[
 			Plugin:          v.Plugin,
 			PluginShortName: v.PluginShortName,
 			Config:          v.Config,
+			Env:             v.Env,
+			Limits:          newConnectionLimits(v.Limits),
 		}
 	}
 	return configMap
 }
+
+// newConnectionLimits converts connection limits to a protobuf ConnectionLimits - unset limits are zero
+func newConnectionLimits(src *modconfig.ConnectionLimits) *pb.ConnectionLimits {
+	if src == nil {
+		return nil
+	}
+	limits := &pb.ConnectionLimits{}
+	if src.MaxMemoryMb != nil {
+		limits.MaxMemoryMb = int64(*src.MaxMemoryMb)
+	}
+	if src.Nice != nil {
+		limits.Nice = int64(*src.Nice)
+	}
+	if src.MaxOpenFiles != nil {
+		limits.MaxOpenFiles = int64(*src.MaxOpenFiles)
+	}
+	return limits
+}
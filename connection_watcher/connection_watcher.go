@@ -58,6 +58,11 @@ func (w *ConnectionWatcher) handleFileWatcherEvent([]fsnotify.Event) {
 		log.Printf("[WARN] Error loading updated connection config: %s", err.Error())
 		return
 	}
+	// do not refresh connections while the schema refresher is updating them
+	// (the lock also guards GlobalConfig, which the schema refresher reads)
+	refreshLock.Lock()
+	defer refreshLock.Unlock()
+
 	client, err := db_local.NewLocalClient(constants.InvokerConnectionWatcher)
 	if err != nil {
 		log.Printf("[WARN] Error creating client to handle updated connection config: %s", err.Error())
@@ -0,0 +1,122 @@
+package connection_watcher
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/db/db_local"
+	"github.com/turbot/steampipe/steampipeconfig"
+)
+
+// how often the schema refresher checks whether any connection schemas are due a refresh
+const schemaRefreshCheckInterval = 10 * time.Second
+
+// refreshLock ensures the connection watcher and the schema refresher do not update connections concurrently
+// it also guards steampipeconfig.GlobalConfig, which the connection watcher replaces when the config changes
+var refreshLock sync.Mutex
+
+// SchemaRefresher periodically re-fetches the schema of connections which have a schema_refresh_interval set
+// and updates the foreign schema of any connection whose dynamic schema has changed
+type SchemaRefresher struct {
+	// the time each connection schema was last refreshed
+	lastRefresh map[string]time.Time
+	startTime   time.Time
+	closeChan   chan struct{}
+	doneChan    chan struct{}
+}
+
+func NewSchemaRefresher() *SchemaRefresher {
+	return &SchemaRefresher{
+		lastRefresh: make(map[string]time.Time),
+		startTime:   time.Now(),
+		closeChan:   make(chan struct{}),
+		doneChan:    make(chan struct{}),
+	}
+}
+
+// Start runs the refresher in a goroutine until Close is called
+func (r *SchemaRefresher) Start() {
+	log.Printf("[INFO] starting SchemaRefresher")
+	go func() {
+		defer close(r.doneChan)
+		ticker := time.NewTicker(schemaRefreshCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.closeChan:
+				return
+			case now := <-ticker.C:
+				r.refreshDueConnections(now)
+			}
+		}
+	}()
+}
+
+func (r *SchemaRefresher) Close() {
+	close(r.closeChan)
+	<-r.doneChan
+}
+
+func (r *SchemaRefresher) refreshDueConnections(now time.Time) {
+	// the connection watcher replaces GlobalConfig while holding the refresh lock,
+	// so the lock must be held while reading it
+	refreshLock.Lock()
+	defer refreshLock.Unlock()
+
+	connections := r.dueConnections(steampipeconfig.GlobalConfig, now)
+	if len(connections) == 0 {
+		return
+	}
+	log.Printf("[TRACE] SchemaRefresher refreshing schema for connections %v", connections)
+
+	// record the refresh time whatever the outcome, so a failing connection is not retried on every check
+	for _, name := range connections {
+		r.lastRefresh[name] = now
+	}
+
+	client, err := db_local.NewLocalClient(constants.InvokerConnectionWatcher)
+	if err != nil {
+		log.Printf("[WARN] Error creating client to refresh connection schemas: %s", err.Error())
+		return
+	}
+	defer client.Close()
+
+	refreshResult := client.RefreshDynamicSchemas(connections)
+	if refreshResult.Error != nil {
+		log.Printf("[WARN] Error refreshing connection schemas: %s", refreshResult.Error.Error())
+		return
+	}
+	for _, w := range refreshResult.Warnings {
+		log.Printf("[WARN] %s", w)
+	}
+	if refreshResult.UpdatedConnections {
+		log.Printf("[INFO] SchemaRefresher updated connection schemas")
+	}
+}
+
+// dueConnections returns the (sorted) names of the connections whose schema refresh interval has elapsed
+func (r *SchemaRefresher) dueConnections(config *steampipeconfig.SteampipeConfig, now time.Time) []string {
+	if config == nil {
+		return nil
+	}
+	var res []string
+	for name := range config.Connections {
+		connectionOptions := config.GetConnectionOptions(name)
+		if connectionOptions.SchemaRefreshInterval == nil || *connectionOptions.SchemaRefreshInterval <= 0 {
+			continue
+		}
+		interval := time.Duration(*connectionOptions.SchemaRefreshInterval) * time.Second
+		lastRefresh, ok := r.lastRefresh[name]
+		if !ok {
+			lastRefresh = r.startTime
+		}
+		if now.Sub(lastRefresh) >= interval {
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
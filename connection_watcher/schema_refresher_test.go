@@ -0,0 +1,104 @@
+package connection_watcher
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/steampipeconfig"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/steampipeconfig/options"
+	"github.com/turbot/steampipe/utils"
+)
+
+var schemaRefresherStartTime = time.Unix(1600000000, 0)
+
+type dueConnectionsTest struct {
+	defaultInterval *int
+	// the schema refresh interval of each connection - nil if the connection has no options
+	connectionIntervals map[string]*int
+	lastRefresh         map[string]time.Time
+	// the time since the refresher started
+	elapsed  time.Duration
+	expected []string
+}
+
+var testCasesDueConnections = map[string]dueConnectionsTest{
+	"no refresh interval": {
+		connectionIntervals: map[string]*int{"a": nil, "b": nil},
+		elapsed:             time.Hour,
+		expected:            nil,
+	},
+	"default interval not elapsed": {
+		defaultInterval:     utils.ToIntegerPointer(60),
+		connectionIntervals: map[string]*int{"a": nil},
+		elapsed:             30 * time.Second,
+		expected:            nil,
+	},
+	"default interval elapsed": {
+		defaultInterval:     utils.ToIntegerPointer(60),
+		connectionIntervals: map[string]*int{"b": nil, "a": nil},
+		elapsed:             60 * time.Second,
+		expected:            []string{"a", "b"},
+	},
+	"connection interval overrides default": {
+		defaultInterval:     utils.ToIntegerPointer(60),
+		connectionIntervals: map[string]*int{"a": nil, "b": utils.ToIntegerPointer(300)},
+		elapsed:             120 * time.Second,
+		expected:            []string{"a"},
+	},
+	"connection interval without default": {
+		connectionIntervals: map[string]*int{"a": nil, "b": utils.ToIntegerPointer(60)},
+		elapsed:             120 * time.Second,
+		expected:            []string{"b"},
+	},
+	"zero interval disables refresh": {
+		defaultInterval:     utils.ToIntegerPointer(60),
+		connectionIntervals: map[string]*int{"a": utils.ToIntegerPointer(0)},
+		elapsed:             time.Hour,
+		expected:            nil,
+	},
+	"interval measured from last refresh": {
+		defaultInterval:     utils.ToIntegerPointer(60),
+		connectionIntervals: map[string]*int{"a": nil, "b": nil},
+		lastRefresh: map[string]time.Time{
+			"a": schemaRefresherStartTime.Add(100 * time.Second),
+		},
+		elapsed:  120 * time.Second,
+		expected: []string{"b"},
+	},
+}
+
+func TestDueConnections(t *testing.T) {
+	for name, test := range testCasesDueConnections {
+		config := &steampipeconfig.SteampipeConfig{
+			Connections:              make(map[string]*modconfig.Connection),
+			DefaultConnectionOptions: &options.Connection{SchemaRefreshInterval: test.defaultInterval},
+		}
+		for connectionName, interval := range test.connectionIntervals {
+			connection := &modconfig.Connection{Name: connectionName}
+			if interval != nil {
+				connection.Options = &options.Connection{SchemaRefreshInterval: interval}
+			}
+			config.Connections[connectionName] = connection
+		}
+
+		refresher := NewSchemaRefresher()
+		refresher.startTime = schemaRefresherStartTime
+		for connectionName, lastRefresh := range test.lastRefresh {
+			refresher.lastRefresh[connectionName] = lastRefresh
+		}
+
+		connections := refresher.dueConnections(config, schemaRefresherStartTime.Add(test.elapsed))
+		if !reflect.DeepEqual(connections, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, connections)
+		}
+	}
+}
+
+func TestDueConnectionsNoConfig(t *testing.T) {
+	refresher := NewSchemaRefresher()
+	if connections := refresher.dueConnections(nil, time.Now().Add(time.Hour)); connections != nil {
+		t.Errorf("expected no connections, got %v", connections)
+	}
+}
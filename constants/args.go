@@ -32,6 +32,22 @@ const (
 	ArgVarFile           = "var-file"
 	ArgConnectionString  = "connection-string"
 	ArgCheckDisplayWidth = "check-display-width"
+	ArgBaseline          = "baseline"
+	ArgDatabaseUrl       = "database-url"
+	ArgReportPort        = "report-port"
+	ArgReportListen      = "report-listen"
+	ArgCacheTTL          = "cache-ttl"
+	ArgPluginIdleTimeout = "plugin-idle-timeout"
+	ArgPassword          = "password"
+	ArgCidr              = "cidr"
+	ArgSslCert           = "database-ssl-cert"
+	ArgSslKey            = "database-ssl-key"
+	ArgSslCa             = "database-ssl-ca"
+	ArgSslClientAuth     = "database-ssl-client-auth"
+	ArgMetricsPort       = "metrics-port"
+	ArgStore             = "store"
+	ArgQueryTimeout      = "query-timeout"
+	ArgShard             = "shard"
 )
 
 /// metaquery mode arguments
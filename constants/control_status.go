@@ -6,4 +6,14 @@ const (
 	ControlSkip  = "skip"
 	ControlInfo  = "info"
 	ControlError = "error"
+	// ControlExempted is the status of an alarm for a resource which matches a mod exemption
+	ControlExempted = "exempted"
+)
+
+// baseline diff statuses - these describe how a control result has changed since a baseline run
+const (
+	ControlDiffNew       = "new"
+	ControlDiffFixed     = "fixed"
+	ControlDiffRegressed = "regressed"
+	ControlDiffUnchanged = "unchanged"
 )
@@ -51,6 +51,9 @@ const (
 	CommandCacheOn              = "cache_on"
 	CommandCacheOff             = "cache_off"
 	CommandCacheClear           = "cache_clear"
+
+	// FdwDefaultCacheTTL is the cache TTL (in seconds) used by the FDW for connections which do not set cache_ttl
+	FdwDefaultCacheTTL = 300
 )
 
 // Functions :: a list of SQLFunc objects that are installed in the db 'internal' schema startup
@@ -91,6 +94,12 @@ func IntrospectionTableNames() []string {
 	return []string{IntrospectionTableControl, IntrospectionTableBenchmark, IntrospectionTableQuery, IntrospectionTableMod, IntrospectionTableVariable, IntrospectionTableReference}
 }
 
+// check history table names - these are created in the public schema when check results are stored
+const (
+	CheckHistoryTableRun    = "steampipe_check_run"
+	CheckHistoryTableResult = "steampipe_check_result"
+)
+
 // Invoker is a pseudoEnum for the command/operation which starts the service
 type Invoker string
 
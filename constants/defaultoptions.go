@@ -9,12 +9,18 @@ const DefaultSPCContent = `
 # options "connection" {
 #   cache     = true # true, false
 #   cache_ttl = 300  # expiration (TTL) in seconds
+#   schema_refresh_interval = 0 # interval (in seconds) at which the service re-fetches dynamic plugin schemas - 0 means never
 # }
 
 # options "database" {
-#   port        = 9193    # any valid, open port number
-#   listen      = "local" # local, network
-#   search_path =  ""     # comma-separated string
+#   port                = 9193    # any valid, open port number
+#   listen              = "local" # local, network
+#   search_path         =  ""     # comma-separated string
+#   plugin_idle_timeout =  0      # idle time (in seconds) before a plugin process is stopped - 0 means never
+#   ssl_cert            =  ""     # server certificate file (default: generated self-signed certificate)
+#   ssl_key             =  ""     # server private key file (required if ssl_cert is set)
+#   ssl_ca              =  ""     # CA certificate file used to verify client certificates
+#   ssl_client_auth     =  false  # true, false - require network clients to present a certificate signed by ssl_ca
 # }
 
 # options "terminal" {
@@ -12,6 +12,8 @@ const (
 	EnvCacheEnabled      = "STEAMPIPE_CACHE"
 	EnvCacheTTL          = "STEAMPIPE_CACHE_TTL"
 	EnvConnectionWatcher = "STEAMPIPE_CONNECTION_WATCHER"
+	// EnvULimit is read by the plugin SDK, which sets the open files limit of the plugin process
+	EnvULimit = "STEAMPIPE_ULIMIT"
 	// EnvInputVarPrefix is the prefix for environment variables that represent values for input variables.
 	EnvInputVarPrefix = "SP_VAR_"
 )
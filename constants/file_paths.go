@@ -10,11 +10,12 @@ import (
 
 // Constants for Config
 const (
-	DefaultInstallDir           = "~/.steampipe"
-	ConnectionsStateFileName    = "connection.json"
-	versionFileName             = "versions.json"
-	databaseRunningInfoFileName = "steampipe.json"
-	pluginManagerStateFileName  = "plugin_manager.json"
+	DefaultInstallDir            = "~/.steampipe"
+	ConnectionsStateFileName     = "connection.json"
+	versionFileName              = "versions.json"
+	databaseRunningInfoFileName  = "steampipe.json"
+	pluginManagerStateFileName   = "plugin_manager.json"
+	pluginManagerPluginsFileName = "plugin_manager_plugins.json"
 )
 
 var SteampipeDir string
@@ -73,6 +74,11 @@ func TempDir() string {
 	return steampipeSubDir("tmp")
 }
 
+// CheckTemplateDir returns the path to the directory containing user-defined check export templates (creates if missing)
+func CheckTemplateDir() string {
+	return steampipeSubDir(filepath.Join("check", "templates"))
+}
+
 // LegacyVersionFilePath returns the legacy version file path
 func LegacyVersionFilePath() string {
 	path := filepath.Join(InternalDir(), versionFileName)
@@ -98,3 +104,8 @@ func RunningInfoFilePath() string {
 func PluginManagerStateFilePath() string {
 	return filepath.Join(InternalDir(), pluginManagerStateFileName)
 }
+
+// PluginManagerPluginsFilePath returns the path of the file containing the plugin processes started by the plugin manager
+func PluginManagerPluginsFilePath() string {
+	return filepath.Join(InternalDir(), pluginManagerPluginsFileName)
+}
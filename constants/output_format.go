@@ -10,4 +10,6 @@ const (
 	OutputFormatMarkdown = "md"
 	OutputFormatTable    = "table"
 	OutputFormatLine     = "line"
+	OutputFormatJUnit    = "junit"
+	OutputFormatSARIF    = "sarif"
 )
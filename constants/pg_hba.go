@@ -43,3 +43,47 @@ host    %[1]s %[2]s samehost trust
 hostssl %[1]s %[2]s all scram-sha-256
 host    %[1]s %[2]s all scram-sha-256
 `
+
+// PgHbaClientCertTemplate is used instead of PgHbaTemplate when the ssl_client_auth database option is set.
+// It is formatted with the same variables.
+var PgHbaClientCertTemplate string = `
+# PostgreSQL Client Authentication Configuration File
+# ===================================================
+#
+# STEAMPIPE
+#
+# The root user is assumed by steampipe to manage the database configuration.
+# Access is not granted to users of steampipe.
+#
+hostssl all root samehost trust
+host    all root samehost trust
+
+# All user queries (steampipe query, steampipe service etc.) are run as the
+# steampipe user.
+#
+# The configuration is:
+# * Access from samehost does not require a password (trust)
+# * Access from any other host requires SSL, a password and a client
+#   certificate signed by the configured ssl_ca
+#
+hostssl %[1]s %[2]s samehost trust
+host    %[1]s %[2]s samehost trust
+hostssl %[1]s %[2]s all scram-sha-256 clientcert=verify-ca
+`
+
+// PgHbaServiceUserTemplate is appended to the PgHbaTemplate for each user added with
+// 'steampipe service user add' and each address range the user may connect from.
+// It is to be formatted with three variables:
+// 		* databaseName
+//		* username
+//		* address (a CIDR or 'all')
+//
+// Service users always require a password - they are not trusted from samehost.
+var PgHbaServiceUserTemplate string = `hostssl %[1]s %[2]s %[3]s scram-sha-256
+host    %[1]s %[2]s %[3]s scram-sha-256
+`
+
+// PgHbaServiceUserClientCertTemplate is used instead of PgHbaServiceUserTemplate when the
+// ssl_client_auth database option is set. It is formatted with the same variables.
+var PgHbaServiceUserClientCertTemplate string = `hostssl %[1]s %[2]s %[3]s scram-sha-256 clientcert=verify-ca
+`
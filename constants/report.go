@@ -0,0 +1,6 @@
+package constants
+
+// report server constants
+const (
+	ReportServerDefaultPort = 5000
+)
@@ -12,6 +12,7 @@ const (
 	WorkspaceIgnoreFile     = ".steampipeignore"
 	WorkspaceDefaultModName = "local"
 	WorkspaceModFileName    = "mod.sp"
+	WorkspaceLockFileName   = ".mod.lock"
 	DefaultVarsFileName     = "steampipe.spvars"
 	MaxControlRunAttempts   = 2
 )
@@ -22,3 +23,6 @@ func WorkspaceModPath(workspacePath string) string {
 func DefaultVarsFilePath(workspacePath string) string {
 	return path.Join(workspacePath, DefaultVarsFileName)
 }
+func WorkspaceLockPath(workspacePath string) string {
+	return path.Join(workspacePath, WorkspaceLockFileName)
+}
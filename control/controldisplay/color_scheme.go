@@ -82,10 +82,12 @@ type ControlColorScheme struct {
 	Spacer               colorFunc
 	Indent               colorFunc
 
-	ReasonColors map[string]colorFunc
-	StatusColors map[string]colorFunc
-	GraphColors  map[string]colorFunc
-	UseColor     bool
+	ReasonColors    map[string]colorFunc
+	StatusColors    map[string]colorFunc
+	GraphColors     map[string]colorFunc
+	DiffColors      map[string]colorFunc
+	DiffGraphColors map[string]colorFunc
+	UseColor        bool
 }
 
 func NewControlColorScheme(def *ControlColorSchemaDefinition) (*ControlColorScheme, error) {
@@ -148,20 +150,37 @@ func (c *ControlColorScheme) Initialise(def *ControlColorSchemaDefinition) error
 		constants.ControlInfo:  c.ReasonInfo,
 		constants.ControlError: c.ReasonError,
 		constants.ControlOk:    c.ReasonOK,
+		// exempted alarms use the skip colors
+		constants.ControlExempted: c.ReasonSkip,
 	}
 	c.StatusColors = map[string]colorFunc{
-		constants.ControlAlarm: c.StatusAlarm,
-		constants.ControlSkip:  c.StatusSkip,
-		constants.ControlInfo:  c.StatusInfo,
-		constants.ControlError: c.StatusError,
-		constants.ControlOk:    c.StatusOK,
+		constants.ControlAlarm:    c.StatusAlarm,
+		constants.ControlSkip:     c.StatusSkip,
+		constants.ControlInfo:     c.StatusInfo,
+		constants.ControlError:    c.StatusError,
+		constants.ControlOk:       c.StatusOK,
+		constants.ControlExempted: c.StatusSkip,
 	}
 	c.GraphColors = map[string]colorFunc{
-		constants.ControlAlarm: c.CountGraphAlarm,
-		constants.ControlSkip:  c.CountGraphSkip,
-		constants.ControlInfo:  c.CountGraphInfo,
-		constants.ControlError: c.CountGraphError,
-		constants.ControlOk:    c.CountGraphOK,
+		constants.ControlAlarm:    c.CountGraphAlarm,
+		constants.ControlSkip:     c.CountGraphSkip,
+		constants.ControlInfo:     c.CountGraphInfo,
+		constants.ControlError:    c.CountGraphError,
+		constants.ControlOk:       c.CountGraphOK,
+		constants.ControlExempted: c.CountGraphSkip,
+	}
+	// baseline diff statuses use the colors of the equivalent control status
+	c.DiffColors = map[string]colorFunc{
+		constants.ControlDiffNew:       c.StatusInfo,
+		constants.ControlDiffFixed:     c.StatusOK,
+		constants.ControlDiffRegressed: c.StatusAlarm,
+		constants.ControlDiffUnchanged: c.StatusSkip,
+	}
+	c.DiffGraphColors = map[string]colorFunc{
+		constants.ControlDiffNew:       c.CountGraphInfo,
+		constants.ControlDiffFixed:     c.CountGraphOK,
+		constants.ControlDiffRegressed: c.CountGraphAlarm,
+		constants.ControlDiffUnchanged: c.CountGraphSkip,
 	}
 
 	c.UseColor = def.UseColor
@@ -109,7 +109,8 @@ func (r ControlRenderer) Render() string {
 	for _, row := range r.run.Rows {
 		resultRenderer := NewResultRenderer(
 			row.Status,
-			row.Reason,
+			resultReason(row),
+			row.BaselineDiff,
 			row.Dimensions,
 			r.colorGenerator,
 			r.width,
@@ -130,3 +131,12 @@ func (r ControlRenderer) Render() string {
 
 	return strings.Join(controlStrings, "\n")
 }
+
+// resultReason returns the reason to display for a result row
+// - for an exempted result, this includes the reason for the exemption
+func resultReason(row *controlexecute.ResultRow) string {
+	if row.ExemptionReason == "" {
+		return row.Reason
+	}
+	return fmt.Sprintf("%s (exempted: %s)", row.Reason, row.ExemptionReason)
+}
@@ -35,6 +35,8 @@ var outputFormatters FormatterMap = FormatterMap{
 	constants.OutputFormatBrief:    &TextFormatter{},
 	constants.OutputFormatHTML:     &HTMLFormatter{},
 	constants.OutputFormatMarkdown: &MarkdownFormatter{},
+	constants.OutputFormatJUnit:    &JUnitFormatter{},
+	constants.OutputFormatSARIF:    &SARIFFormatter{},
 }
 
 var exportFormatters FormatterMap = FormatterMap{
@@ -42,6 +44,8 @@ var exportFormatters FormatterMap = FormatterMap{
 	constants.OutputFormatJSON:     &JSONFormatter{},
 	constants.OutputFormatHTML:     &HTMLFormatter{},
 	constants.OutputFormatMarkdown: &MarkdownFormatter{},
+	constants.OutputFormatJUnit:    &JUnitFormatter{},
+	constants.OutputFormatSARIF:    &SARIFFormatter{},
 }
 
 type CheckExportTarget struct {
@@ -66,6 +70,9 @@ type Formatter interface {
 func GetExportFormatter(exportFormat string) (Formatter, error) {
 	formatter, found := exportFormatters[exportFormat]
 	if !found {
+		if loadErr, ok := templateLoadErrors[exportFormat]; ok {
+			return nil, fmt.Errorf("failed to load export template '%s': %s", exportFormat, loadErr.Error())
+		}
 		return nil, fmt.Errorf("invalid export format '%s' - must be one of %s", exportFormat, exportFormatters.keys())
 	}
 	return formatter, nil
@@ -90,7 +97,15 @@ func InferFormatFromExportFileName(filename string) (string, error) {
 		return constants.OutputFormatHTML, nil
 	case ".md", ".markdown":
 		return constants.OutputFormatMarkdown, nil
+	case ".xml":
+		return constants.OutputFormatJUnit, nil
+	case ".sarif":
+		return constants.OutputFormatSARIF, nil
 	default:
+		// check whether a user-defined template has this extension
+		if format, ok := inferTemplateFormat(extension); ok {
+			return format, nil
+		}
 		// could not infer format
 		return "", fmt.Errorf("could not infer valid export format from filename '%s'", filename)
 	}
@@ -113,6 +128,13 @@ var formatterTemplateFuncMap template.FuncMap = template.FuncMap{
 	"steampipeversion": func() string { return version.String() },
 	"workingdir":       func() string { wd, _ := os.Getwd(); return wd },
 	"asstr":            func(i reflect.Value) string { return fmt.Sprintf("%v", i) },
+	"diffmarker": func(diff string) string {
+		// only highlight results which have changed since the baseline run
+		if diff == "" || diff == constants.ControlDiffUnchanged {
+			return ""
+		}
+		return fmt.Sprintf("**%s** ", strings.ToUpper(diff))
+	},
 	"statusicon": func(status string) string {
 		switch strings.ToLower(status) {
 		case "ok":
@@ -125,6 +147,8 @@ var formatterTemplateFuncMap template.FuncMap = template.FuncMap{
 			return "❌"
 		case "error":
 			return "❗"
+		case "exempted":
+			return "⊘"
 		}
 		return ""
 	},
@@ -155,6 +179,11 @@ var formatterTemplateFuncMap template.FuncMap = template.FuncMap{
 				return "summary-total-error highlight"
 			}
 			return "summary-total-error"
+		case "exempted":
+			if total > 0 {
+				return "summary-total-exempted highlight"
+			}
+			return "summary-total-exempted"
 		}
 		return ""
 	},
@@ -0,0 +1,215 @@
+package controldisplay
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controlexecute"
+)
+
+// JUnitFormatter exports check results as JUnit XML
+// each control run maps to a test case, grouped into a test suite per result group,
+// and each alarm or error result maps to a failure or error of the test case
+// (exempted alarms are not failures, but are counted in the 'exempted' property of the test case)
+type JUnitFormatter struct{}
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Id        string           `xml:"id,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      string           `xml:"time,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	Classname  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	File       string           `xml:"file,attr,omitempty"`
+	Line       int              `xml:"line,attr,omitempty"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Skipped    *junitSkipped    `xml:"skipped,omitempty"`
+	Failures   []*junitFailure  `xml:"failure"`
+	Errors     []*junitFailure  `xml:"error"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func (j *JUnitFormatter) Format(_ context.Context, tree *controlexecute.ExecutionTree) (io.Reader, error) {
+	suites := &junitTestSuites{
+		Name: "Steampipe",
+		Time: formatSeconds(tree.EndTime.Sub(tree.StartTime).Seconds()),
+	}
+	j.addGroup(tree.Root, suites)
+	for _, s := range suites.Suites {
+		suites.Tests += s.Tests
+		suites.Failures += s.Failures
+		suites.Errors += s.Errors
+	}
+
+	outBuffer := bytes.NewBufferString(xml.Header)
+	encoder := xml.NewEncoder(outBuffer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return nil, err
+	}
+	return strings.NewReader(outBuffer.String()), nil
+}
+
+func (j *JUnitFormatter) FileExtension() string {
+	return "xml"
+}
+
+// addGroup adds a test suite for the group (if it has any control runs), then recurses into child groups
+// (JUnit consumers do not generally support nested test suites so the hierarchy is flattened)
+func (j *JUnitFormatter) addGroup(group *controlexecute.ResultGroup, suites *junitTestSuites) {
+	if len(group.ControlRuns) > 0 {
+		suite := &junitTestSuite{
+			Name: group.Title,
+			Id:   group.GroupId,
+			Time: formatSeconds(group.Duration.Seconds()),
+		}
+		if suite.Name == "" {
+			suite.Name = group.GroupId
+		}
+		for _, run := range group.ControlRuns {
+			testCase := j.testCaseForRun(run)
+			suite.Tests++
+			if len(testCase.Failures) > 0 {
+				suite.Failures++
+			}
+			if len(testCase.Errors) > 0 {
+				suite.Errors++
+			}
+			if testCase.Skipped != nil {
+				suite.Skipped++
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+	for _, child := range group.Groups {
+		j.addGroup(child, suites)
+	}
+}
+
+func (j *JUnitFormatter) testCaseForRun(run *controlexecute.ControlRun) *junitTestCase {
+	testCase := &junitTestCase{
+		Name:      run.Title,
+		Classname: run.ControlId,
+		Time:      formatSeconds(run.Duration.Seconds()),
+	}
+	if testCase.Name == "" {
+		testCase.Name = run.ControlId
+	}
+	if run.Control != nil {
+		testCase.File = sourceFileForDisplay(run.Control.DeclRange.Filename)
+		testCase.Line = run.Control.DeclRange.Start.Line
+	}
+	if properties := j.propertiesForRun(run); len(properties) > 0 {
+		testCase.Properties = &junitProperties{Properties: properties}
+	}
+
+	// if the control run failed, add an error
+	if err := run.GetError(); err != nil {
+		testCase.Errors = append(testCase.Errors, &junitFailure{
+			Message: err.Error(),
+			Type:    constants.ControlError,
+		})
+		return testCase
+	}
+
+	for _, row := range run.Rows {
+		switch row.Status {
+		case constants.ControlAlarm:
+			testCase.Failures = append(testCase.Failures, j.failureForRow(row))
+		case constants.ControlError:
+			testCase.Errors = append(testCase.Errors, j.failureForRow(row))
+		}
+	}
+
+	// if all results were skipped, mark the test case as skipped
+	if run.Summary.Skip > 0 && run.Summary.Skip == run.Summary.TotalCount() {
+		testCase.Skipped = &junitSkipped{}
+	}
+	return testCase
+}
+
+func (j *JUnitFormatter) propertiesForRun(run *controlexecute.ControlRun) []junitProperty {
+	var properties []junitProperty
+	if run.Severity != "" {
+		properties = append(properties, junitProperty{Name: "severity", Value: run.Severity})
+	}
+	for _, key := range sortedKeys(run.Tags) {
+		properties = append(properties, junitProperty{Name: fmt.Sprintf("tag.%s", key), Value: run.Tags[key]})
+	}
+	if run.Summary.Exempted > 0 {
+		properties = append(properties, junitProperty{Name: "exempted", Value: fmt.Sprintf("%d", run.Summary.Exempted)})
+	}
+	return append(properties, j.dimensionPropertiesForRun(run)...)
+}
+
+// dimensionPropertiesForRun returns a 'dimension.<key>' property for each distinct dimension value
+// of the alarm and error results of the run
+func (j *JUnitFormatter) dimensionPropertiesForRun(run *controlexecute.ControlRun) []junitProperty {
+	var properties []junitProperty
+	added := make(map[junitProperty]bool)
+	for _, row := range run.Rows {
+		if row.Status != constants.ControlAlarm && row.Status != constants.ControlError {
+			continue
+		}
+		for _, dimension := range row.Dimensions {
+			property := junitProperty{Name: fmt.Sprintf("dimension.%s", dimension.Key), Value: dimension.Value}
+			if !added[property] {
+				added[property] = true
+				properties = append(properties, property)
+			}
+		}
+	}
+	return properties
+}
+
+// failureForRow builds a failure for the result row - the body contains the resource
+// (the dimensions are added as properties of the test case)
+func (j *JUnitFormatter) failureForRow(row *controlexecute.ResultRow) *junitFailure {
+	return &junitFailure{
+		Message: row.Reason,
+		Type:    row.Status,
+		Body:    fmt.Sprintf("resource: %s", row.Resource),
+	}
+}
@@ -0,0 +1,236 @@
+package controldisplay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/version"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+)
+
+// SARIFFormatter exports check results in the SARIF 2.1.0 format
+// each control run maps to a rule, and each alarm or error result maps to a result of that rule
+// exempted alarms map to suppressed results
+type SARIFFormatter struct{}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool      `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version"`
+	InformationUri string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string                 `json:"id"`
+	Name             string                 `json:"name,omitempty"`
+	ShortDescription *sarifMessage          `json:"shortDescription,omitempty"`
+	FullDescription  *sarifMessage          `json:"fullDescription,omitempty"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId       string                 `json:"ruleId"`
+	RuleIndex    int                    `json:"ruleIndex"`
+	Level        string                 `json:"level"`
+	Message      sarifMessage           `json:"message"`
+	Locations    []sarifLocation        `json:"locations,omitempty"`
+	Properties   map[string]interface{} `json:"properties,omitempty"`
+	Suppressions []sarifSuppression     `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func (s *SARIFFormatter) Format(_ context.Context, tree *controlexecute.ExecutionTree) (io.Reader, error) {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "Steampipe",
+				Version:        version.String(),
+				InformationUri: "https://steampipe.io",
+				Rules:          []*sarifRule{},
+			},
+		},
+		Results: []*sarifResult{},
+	}
+	s.addGroup(tree.Root, &run)
+
+	sarif := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	}
+	outBuffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(outBuffer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(sarif); err != nil {
+		return nil, err
+	}
+	return strings.NewReader(outBuffer.String()), nil
+}
+
+func (s *SARIFFormatter) FileExtension() string {
+	return "sarif"
+}
+
+// addGroup adds a rule for each control run in the group, then recurses into child groups
+func (s *SARIFFormatter) addGroup(group *controlexecute.ResultGroup, run *sarifRun) {
+	for _, controlRun := range group.ControlRuns {
+		s.addControlRun(controlRun, run)
+	}
+	for _, child := range group.Groups {
+		s.addGroup(child, run)
+	}
+}
+
+func (s *SARIFFormatter) addControlRun(controlRun *controlexecute.ControlRun, run *sarifRun) {
+	ruleIndex := len(run.Tool.Driver.Rules)
+	run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, s.ruleForControlRun(controlRun))
+	locations := s.locationsForControlRun(controlRun)
+
+	// if the control run failed, add a single error result
+	if err := controlRun.GetError(); err != nil {
+		run.Results = append(run.Results, &sarifResult{
+			RuleId:    controlRun.ControlId,
+			RuleIndex: ruleIndex,
+			Level:     "error",
+			Message:   sarifMessage{Text: err.Error()},
+			Locations: locations,
+		})
+		return
+	}
+
+	for _, row := range controlRun.Rows {
+		var level string
+		switch row.Status {
+		case constants.ControlAlarm, constants.ControlExempted:
+			level = sarifLevelForSeverity(controlRun.Severity)
+		case constants.ControlError:
+			level = "error"
+		default:
+			continue
+		}
+		result := &sarifResult{
+			RuleId:     controlRun.ControlId,
+			RuleIndex:  ruleIndex,
+			Level:      level,
+			Message:    sarifMessage{Text: row.Reason},
+			Locations:  locations,
+			Properties: map[string]interface{}{"status": row.Status, "resource": row.Resource},
+		}
+		if len(row.Dimensions) > 0 {
+			dimensions := map[string]string{}
+			for _, dimension := range row.Dimensions {
+				dimensions[dimension.Key] = dimension.Value
+			}
+			result.Properties["dimensions"] = dimensions
+		}
+		// an exempted alarm is reported as a result suppressed by the mod exemption
+		if row.Status == constants.ControlExempted {
+			result.Suppressions = []sarifSuppression{{Kind: "external", Justification: row.ExemptionReason}}
+		}
+		run.Results = append(run.Results, result)
+	}
+}
+
+func (s *SARIFFormatter) ruleForControlRun(controlRun *controlexecute.ControlRun) *sarifRule {
+	rule := &sarifRule{
+		Id:   controlRun.ControlId,
+		Name: controlRun.ControlId,
+	}
+	if controlRun.Title != "" {
+		rule.ShortDescription = &sarifMessage{Text: controlRun.Title}
+	}
+	if controlRun.Description != "" {
+		rule.FullDescription = &sarifMessage{Text: controlRun.Description}
+	}
+	properties := map[string]interface{}{}
+	if controlRun.Severity != "" {
+		properties["severity"] = controlRun.Severity
+	}
+	if len(controlRun.Tags) > 0 {
+		properties["tags"] = controlRun.Tags
+	}
+	if len(properties) > 0 {
+		rule.Properties = properties
+	}
+	return rule
+}
+
+// locationsForControlRun returns the location of the control definition
+// (control runs loaded from a JSON export have no control, so have no location)
+func (s *SARIFFormatter) locationsForControlRun(controlRun *controlexecute.ControlRun) []sarifLocation {
+	if controlRun.Control == nil || controlRun.Control.DeclRange.Filename == "" {
+		return nil
+	}
+	declRange := controlRun.Control.DeclRange
+	return []sarifLocation{{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{Uri: sourceFileForDisplay(declRange.Filename)},
+			Region: &sarifRegion{
+				StartLine:   declRange.Start.Line,
+				StartColumn: declRange.Start.Column,
+			},
+		},
+	}}
+}
+
+// sarifLevelForSeverity maps a control severity to a SARIF result level
+func sarifLevelForSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "low", "info", "none":
+		return "note"
+	case "medium":
+		return "warning"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,147 @@
+package controldisplay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/utils"
+)
+
+// the entry point of a user-defined template is the file named index.tmpl.<extension>
+// (optionally with a prefix, for consistency with the built-in templates, e.g. 001.index.tmpl.html)
+const templateIndexFileName = "index.tmpl."
+
+// TemplateFormatter is an export formatter defined by a user-provided folder of go templates
+// the templates are passed the execution tree and have access to the same template functions
+// as the built-in HTML and markdown formatters
+type TemplateFormatter struct {
+	name          string
+	templateDir   string
+	indexTemplate string
+	extension     string
+}
+
+// NewTemplateFormatter creates a TemplateFormatter from the templates in templateDir
+// the formatter name is the name of the folder, and the file extension is taken from the index template
+func NewTemplateFormatter(templateDir string) (*TemplateFormatter, error) {
+	entries, err := os.ReadDir(templateDir)
+	if err != nil {
+		return nil, err
+	}
+	// sort the files so the index template chosen is deterministic
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	name := filepath.Base(templateDir)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		idx := strings.Index(entry.Name(), templateIndexFileName)
+		if idx == -1 {
+			continue
+		}
+		extension := entry.Name()[idx+len(templateIndexFileName):]
+		if extension == "" {
+			return nil, fmt.Errorf("index template '%s' of template '%s' has no file extension", entry.Name(), name)
+		}
+		return &TemplateFormatter{
+			name:          name,
+			templateDir:   templateDir,
+			indexTemplate: entry.Name(),
+			extension:     extension,
+		}, nil
+	}
+	return nil, fmt.Errorf("template '%s' has no index template - expected a file named %s<extension>", name, templateIndexFileName)
+}
+
+func (f *TemplateFormatter) Format(ctx context.Context, tree *controlexecute.ExecutionTree) (io.Reader, error) {
+	t, err := template.
+		New(f.indexTemplate).
+		Funcs(formatterTemplateFuncMap).
+		ParseFS(os.DirFS(f.templateDir), "*")
+	if err != nil {
+		return nil, err
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		if err := t.Execute(writer, tree); err != nil {
+			writer.CloseWithError(err)
+		} else {
+			writer.Close()
+		}
+	}()
+	return reader, nil
+}
+
+func (f *TemplateFormatter) FileExtension() string {
+	return f.extension
+}
+
+// templateLoadErrors is the error for each user-defined template which failed to load, keyed by template name
+// this is returned by GetExportFormatter if the template is used as an export format
+var templateLoadErrors = make(map[string]error)
+
+// LoadTemplateFormatters registers an export formatter for each template folder in templateRootDir
+// a template which cannot be loaded, or whose name clashes with an existing export format, is not registered
+// and an error is returned for it
+func LoadTemplateFormatters(templateRootDir string) error {
+	entries, err := os.ReadDir(templateRootDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var errors []error
+	for _, entry := range entries {
+		if !isDir(templateRootDir, entry) {
+			continue
+		}
+		formatter, err := NewTemplateFormatter(filepath.Join(templateRootDir, entry.Name()))
+		if err != nil {
+			templateLoadErrors[entry.Name()] = err
+			errors = append(errors, err)
+			continue
+		}
+		if _, ok := exportFormatters[formatter.name]; ok {
+			errors = append(errors, fmt.Errorf("template '%s' has the same name as an existing export format", formatter.name))
+			continue
+		}
+		exportFormatters[formatter.name] = formatter
+	}
+	if len(errors) > 0 {
+		return utils.CombineErrorsWithPrefix(fmt.Sprintf("failed to load %d check %s", len(errors), utils.Pluralize("template", len(errors))), errors...)
+	}
+	return nil
+}
+
+// inferTemplateFormat returns the name of the user-defined template whose file extension matches the given extension
+func inferTemplateFormat(extension string) (string, bool) {
+	// sort the formats so the result is deterministic if templates share an extension
+	formats := exportFormatters.keys()
+	sort.Strings(formats)
+	for _, format := range formats {
+		if formatter, ok := exportFormatters[format].(*TemplateFormatter); ok && "."+formatter.extension == extension {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// isDir returns whether the entry is a directory, following symlinks
+func isDir(parent string, entry fs.DirEntry) bool {
+	if entry.IsDir() {
+		return true
+	}
+	info, err := os.Stat(filepath.Join(parent, entry.Name()))
+	return err == nil && info.IsDir()
+}
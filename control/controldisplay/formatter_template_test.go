@@ -0,0 +1,63 @@
+package controldisplay
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+const expectedTemplateOutput = `= Test Root Group
+
+* ❌ is pretty insecure
+* ❌ is pretty insecure
+* ❌ is pretty insecure
+* ❌ is pretty insecure`
+
+func TestTemplateFormatter(t *testing.T) {
+	err := LoadTemplateFormatters("test_data/templates")
+	defer func() {
+		delete(exportFormatters, "asciidoc")
+		delete(templateLoadErrors, "no_index")
+	}()
+
+	// the 'no_index' template has no index template, and the 'json' template clashes with the json format
+	if err == nil || !strings.Contains(err.Error(), "no_index") || !strings.Contains(err.Error(), "json") {
+		t.Fatalf("expected errors loading the 'no_index' and 'json' templates, got %v", err)
+	}
+	if _, ok := exportFormatters["json"].(*JSONFormatter); !ok {
+		t.Fatal("template 'json' replaced the built-in json formatter")
+	}
+
+	// using a template which failed to load as an export format should return the load error
+	if _, err := GetExportFormatter("no_index"); err == nil || !strings.Contains(err.Error(), "no index template") {
+		t.Fatalf("expected the load error of the 'no_index' template, got %v", err)
+	}
+
+	f, err := GetExportFormatter("asciidoc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.FileExtension() != "adoc" {
+		t.Fatalf("expected file extension 'adoc', got '%s'", f.FileExtension())
+	}
+	if format, err := InferFormatFromExportFileName("report.adoc"); err != nil || format != "asciidoc" {
+		t.Fatalf("expected to infer format 'asciidoc' from 'report.adoc', got '%s' (%v)", format, err)
+	}
+
+	reader, err := f.Format(context.Background(), tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := bytes.NewBufferString("")
+	if _, err := io.Copy(b, reader); err != nil {
+		t.Fatal(err)
+	}
+	output := strings.TrimSpace(b.String())
+	if output != expectedTemplateOutput {
+		t.Log(`"expected" is not equal to "output"`)
+		t.Log(output)
+		t.FailNow()
+	}
+}
@@ -3,7 +3,10 @@ package controldisplay
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"encoding/xml"
 	"io"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -285,3 +288,115 @@ func TestHtmlFormatter(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestJUnitFormatter(t *testing.T) {
+	f := new(JUnitFormatter)
+	reader, err := f.Format(context.Background(), tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := bytes.NewBufferString("")
+	_, _ = io.Copy(b, reader)
+	output := b.String()
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal([]byte(output), &suites); err != nil {
+		t.Log(output)
+		t.Fatal(err)
+	}
+	if len(suites.Suites) != 2 || suites.Tests != 4 || suites.Failures != 4 {
+		t.Log(output)
+		t.Fatalf("expected 2 test suites with 4 tests and 4 failures, got %d test suites with %d tests and %d failures", len(suites.Suites), suites.Tests, suites.Failures)
+	}
+	failure := suites.Suites[0].TestCases[0].Failures[0]
+	if failure.Message != "is pretty insecure" || failure.Body != "resource: some other resource" {
+		t.Log(output)
+		t.FailNow()
+	}
+}
+
+func TestJUnitDimensionProperties(t *testing.T) {
+	run := &controlexecute.ControlRun{
+		ControlId: "control.c1",
+		Rows: []*controlexecute.ResultRow{
+			{
+				Status:     constants.ControlAlarm,
+				Reason:     "is pretty insecure",
+				Resource:   "resource 1",
+				Dimensions: []controlexecute.Dimension{{Key: "region", Value: "us-east-1"}, {Key: "account", Value: "123"}},
+			},
+			{
+				Status:     constants.ControlAlarm,
+				Reason:     "is pretty insecure",
+				Resource:   "resource 2",
+				Dimensions: []controlexecute.Dimension{{Key: "region", Value: "us-east-2"}, {Key: "account", Value: "123"}},
+			},
+			{
+				Status:     constants.ControlOk,
+				Reason:     "is secure",
+				Resource:   "resource 3",
+				Dimensions: []controlexecute.Dimension{{Key: "region", Value: "eu-west-1"}},
+			},
+		},
+	}
+	testCase := new(JUnitFormatter).testCaseForRun(run)
+
+	expected := []junitProperty{
+		{Name: "dimension.region", Value: "us-east-1"},
+		{Name: "dimension.account", Value: "123"},
+		{Name: "dimension.region", Value: "us-east-2"},
+	}
+	if testCase.Properties == nil || !reflect.DeepEqual(testCase.Properties.Properties, expected) {
+		t.Errorf("expected properties %v, got %v", expected, testCase.Properties)
+	}
+	if len(testCase.Failures) != 2 || testCase.Failures[0].Body != "resource: resource 1" {
+		t.Errorf("expected 2 failures with the resource as the body, got %v", testCase.Failures)
+	}
+}
+
+func TestSarifFormatter(t *testing.T) {
+	f := new(SARIFFormatter)
+	reader, err := f.Format(context.Background(), tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := bytes.NewBufferString("")
+	_, _ = io.Copy(b, reader)
+	output := b.String()
+
+	var sarif sarifLog
+	if err := json.Unmarshal([]byte(output), &sarif); err != nil {
+		t.Log(output)
+		t.Fatal(err)
+	}
+	if len(sarif.Runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(sarif.Runs))
+	}
+	run := sarif.Runs[0]
+	if len(run.Tool.Driver.Rules) != 4 || len(run.Results) != 4 {
+		t.Log(output)
+		t.Fatalf("expected 4 rules and 4 results, got %d rules and %d results", len(run.Tool.Driver.Rules), len(run.Results))
+	}
+	for i, result := range run.Results {
+		if result.RuleIndex != i || result.Level != "error" || result.Message.Text != "is pretty insecure" {
+			t.Log(output)
+			t.FailNow()
+		}
+	}
+}
+
+func TestSarifLevelForSeverity(t *testing.T) {
+	testCases := map[string]string{
+		"critical": "error",
+		"high":     "error",
+		"medium":   "warning",
+		"low":      "note",
+		"info":     "note",
+		"":         "error",
+	}
+	for severity, expected := range testCases {
+		if level := sarifLevelForSeverity(severity); level != expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s, got %s", severity, expected, level)
+		}
+	}
+}
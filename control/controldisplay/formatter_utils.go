@@ -0,0 +1,37 @@
+package controldisplay
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// sortedKeys returns the keys of the map, sorted alphabetically
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatSeconds formats a duration in seconds with millisecond precision
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
+
+// sourceFileForDisplay returns the path of a source file relative to the working directory
+// (if the file is not within the working directory, the path is returned unchanged)
+func sourceFileForDisplay(filename string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+	relPath, err := filepath.Rel(wd, filename)
+	if err != nil || filepath.IsAbs(relPath) || len(relPath) >= 2 && relPath[:2] == ".." {
+		return filepath.ToSlash(filename)
+	}
+	return filepath.ToSlash(relPath)
+}
@@ -14,6 +14,7 @@ const minReasonWidth = 10
 type ResultRenderer struct {
 	status         string
 	reason         string
+	diff           string
 	dimensions     []controlexecute.Dimension
 	colorGenerator *controlexecute.DimensionColorGenerator
 
@@ -24,10 +25,11 @@ type ResultRenderer struct {
 	indent     string
 }
 
-func NewResultRenderer(status, reason string, dimensions []controlexecute.Dimension, colorGenerator *controlexecute.DimensionColorGenerator, width int, indent string) *ResultRenderer {
+func NewResultRenderer(status, reason, diff string, dimensions []controlexecute.Dimension, colorGenerator *controlexecute.DimensionColorGenerator, width int, indent string) *ResultRenderer {
 	return &ResultRenderer{
 		status:         status,
 		reason:         reason,
+		diff:           diff,
 		dimensions:     dimensions,
 		colorGenerator: colorGenerator,
 		width:          width,
@@ -46,11 +48,15 @@ func (r ResultRenderer) Render() string {
 	statusString := status.Render()
 	statusWidth := helpers.PrintableLength(statusString)
 
+	// if a baseline was specified, render the diff status after the status
+	diffString := NewResultDiffRenderer(r.diff).Render()
+	diffWidth := helpers.PrintableLength(diffString)
+
 	formattedIndent := fmt.Sprintf("%s", ControlColors.Indent(r.indent))
 	indentWidth := helpers.PrintableLength(formattedIndent)
 
 	// figure out how much width we have available for the  dimensions, allowing the minimum for the reason
-	availableWidth := r.width - statusWidth - indentWidth
+	availableWidth := r.width - statusWidth - diffWidth - indentWidth
 
 	// for now give this all to reason
 	availableDimensionWidth := availableWidth - minReasonWidth
@@ -74,6 +80,6 @@ func (r ResultRenderer) Render() string {
 	}
 
 	// now put these all together
-	str := fmt.Sprintf("%s%s%s%s%s", formattedIndent, statusString, reasonString, spacerString, dimensionsString)
+	str := fmt.Sprintf("%s%s%s%s%s%s", formattedIndent, statusString, diffString, reasonString, spacerString, dimensionsString)
 	return str
 }
@@ -0,0 +1,32 @@
+package controldisplay
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/constants"
+)
+
+type ResultDiffRenderer struct {
+	diff string
+}
+
+func NewResultDiffRenderer(diff string) *ResultDiffRenderer {
+	return &ResultDiffRenderer{
+		diff: diff,
+	}
+}
+
+// Render returns the baseline diff status of the result, e.g. "[NEW] "
+// unchanged results (and results with no baseline) render nothing
+// NOTE: adds a trailing space
+func (r ResultDiffRenderer) Render() string {
+	if r.diff == "" || r.diff == constants.ControlDiffUnchanged {
+		return ""
+	}
+	colorFunc, ok := ControlColors.DiffColors[r.diff]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s ", colorFunc(fmt.Sprintf("[%s]", strings.ToUpper(r.diff))))
+}
@@ -0,0 +1,44 @@
+package controldisplay
+
+import (
+	"fmt"
+	"testing"
+)
+
+type resultDiffTest struct {
+	diff     string
+	expected string
+}
+
+func testCasesResultDiff() map[string]resultDiffTest {
+	return map[string]resultDiffTest{
+		"new": {
+			diff:     "new",
+			expected: fmt.Sprintf("%s ", ControlColors.DiffColors["new"]("[NEW]")),
+		},
+		"regressed": {
+			diff:     "regressed",
+			expected: fmt.Sprintf("%s ", ControlColors.DiffColors["regressed"]("[REGRESSED]")),
+		},
+		"unchanged": {
+			diff:     "unchanged",
+			expected: "",
+		},
+		"no baseline": {
+			diff:     "",
+			expected: "",
+		},
+	}
+}
+
+func TestResultDiff(t *testing.T) {
+	themeDef := ColorSchemes["dark"]
+	scheme, _ := NewControlColorScheme(themeDef)
+	ControlColors = scheme
+	for name, test := range testCasesResultDiff() {
+		output := NewResultDiffRenderer(test.diff).Render()
+		if output != test.expected {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %v \ngot:\n %v\n", name, test.expected, output)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/control/controlexecute"
 )
 
@@ -48,6 +49,7 @@ func (r SummaryRenderer) Render() string {
 	infoStatusRow := NewSummaryStatusRowRenderer(r.resultTree, availableWidth, "info").Render()
 	alarmStatusRow := NewSummaryStatusRowRenderer(r.resultTree, availableWidth, "alarm").Render()
 	errorStatusRow := NewSummaryStatusRowRenderer(r.resultTree, availableWidth, "error").Render()
+	exemptedStatusRow := NewSummaryStatusRowRenderer(r.resultTree, availableWidth, "exempted").Render()
 
 	titleLine := fmt.Sprintf("%s\n", ControlColors.GroupTitle("Summary"))
 
@@ -60,6 +62,17 @@ func (r SummaryRenderer) Render() string {
 		infoStatusRow,
 		alarmStatusRow,
 		errorStatusRow,
+		exemptedStatusRow,
+	}
+	// if the results were compared with a baseline, add the diff summaries
+	if r.resultTree.HasBaseline() {
+		summaryLines = append(summaryLines,
+			"", // blank line
+			NewSummaryDiffRowRenderer(r.resultTree, availableWidth, constants.ControlDiffNew).Render(),
+			NewSummaryDiffRowRenderer(r.resultTree, availableWidth, constants.ControlDiffFixed).Render(),
+			NewSummaryDiffRowRenderer(r.resultTree, availableWidth, constants.ControlDiffRegressed).Render(),
+			NewSummaryDiffRowRenderer(r.resultTree, availableWidth, constants.ControlDiffUnchanged).Render(),
+		)
 	}
 	// if there is a severity block, add it
 	if len(severityRows) > 0 {
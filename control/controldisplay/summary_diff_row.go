@@ -0,0 +1,67 @@
+package controldisplay
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controlexecute"
+)
+
+type SummaryDiffRowRenderer struct {
+	resultTree *controlexecute.ExecutionTree
+	width      int
+	diff       string
+}
+
+func NewSummaryDiffRowRenderer(resultTree *controlexecute.ExecutionTree, width int, diff string) *SummaryDiffRowRenderer {
+	return &SummaryDiffRowRenderer{
+		resultTree: resultTree,
+		width:      width,
+		diff:       diff,
+	}
+}
+
+func (r *SummaryDiffRowRenderer) Render() string {
+	txtColorFunction := ControlColors.DiffColors[r.diff]
+	graphColorFunction := ControlColors.DiffGraphColors[r.diff]
+
+	diffSummary := r.resultTree.Root.Summary.Diff
+	count := -1
+	switch r.diff {
+	case constants.ControlDiffNew:
+		count = diffSummary.New
+	case constants.ControlDiffFixed:
+		count = diffSummary.Fixed
+	case constants.ControlDiffRegressed:
+		count = diffSummary.Regressed
+	case constants.ControlDiffUnchanged:
+		count = diffSummary.Unchanged
+	default:
+		// we can safely panic here, since the diff status is determined by the executor
+		panic(fmt.Sprintf("unknown diff status: %s", r.diff))
+	}
+	countString := getPrintableNumber(count, txtColorFunction)
+
+	graph := NewCounterGraphRenderer(
+		count,
+		count,
+		r.resultTree.Root.Summary.Status.TotalCount(),
+		CounterGraphRendererOptions{
+			FailedColorFunc: graphColorFunction,
+		},
+	).Render()
+
+	diffStr := fmt.Sprintf("%s ", txtColorFunction(strings.ToUpper(r.diff)))
+	spaceAvailableForSpacer := r.width - (helpers.PrintableLength(diffStr) + helpers.PrintableLength(countString) + helpers.PrintableLength(graph))
+	spacer := NewSpacerRenderer(spaceAvailableForSpacer)
+
+	return fmt.Sprintf(
+		"%s%s%s%s",
+		diffStr,
+		spacer.Render(),
+		countString,
+		graph,
+	)
+}
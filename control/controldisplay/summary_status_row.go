@@ -41,12 +41,14 @@ func (r *SummaryStatusRowRenderer) Render() string {
 		count = r.resultTree.Root.Summary.Status.Alarm
 	case constants.ControlError:
 		count = r.resultTree.Root.Summary.Status.Error
+	case constants.ControlExempted:
+		count = r.resultTree.Root.Summary.Status.Exempted
 	default:
 		// we can safely panic here, since the status enum check should have been
 		// done by the executor. this is here for unit tests mostly
 		panic(fmt.Sprintf("unknown status: %s", r.status))
 	}
-	countString := r.getPrintableNumber(count, txtColorFunction)
+	countString := getPrintableNumber(count, txtColorFunction)
 
 	graph := NewCounterGraphRenderer(
 		count,
@@ -70,7 +72,8 @@ func (r *SummaryStatusRowRenderer) Render() string {
 	)
 }
 
-func (r *SummaryStatusRowRenderer) getPrintableNumber(number int, cf colorFunc) string {
+// getPrintableNumber formats the number with thousands separators, colors it and adds a trailing space
+func getPrintableNumber(number int, cf colorFunc) string {
 	p := message.NewPrinter(language.English)
 	s := p.Sprintf("%d", number)
 	return fmt.Sprintf("%s ", cf(s))
@@ -0,0 +1,112 @@
+package controlexecute
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/turbot/steampipe/constants"
+)
+
+// Baseline is a struct containing the results of a previous check run, loaded from a JSON export
+// it is used to determine how the results of the current run differ from the previous run
+type Baseline struct {
+	// map of control id to resource to status
+	statuses map[string]map[string]string
+}
+
+// LoadBaseline reads a JSON check export and builds a Baseline from the results
+func LoadBaseline(path string) (*Baseline, error) {
+	root, err := readResultExport(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load baseline file %s: %s", path, err.Error())
+	}
+
+	return NewBaseline(root), nil
+}
+
+// NewBaseline builds a Baseline from the results of a previous check run
+func NewBaseline(root *ResultGroup) *Baseline {
+	res := &Baseline{statuses: make(map[string]map[string]string)}
+	res.addGroup(root)
+	return res
+}
+
+// readResultExport reads the root ResultGroup of a JSON check export
+func readResultExport(path string) (*ResultGroup, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var root ResultGroup
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	return &root, nil
+}
+
+func (b *Baseline) addGroup(group *ResultGroup) {
+	for _, run := range group.ControlRuns {
+		resourceStatuses, ok := b.statuses[run.ControlId]
+		if !ok {
+			resourceStatuses = make(map[string]string)
+			b.statuses[run.ControlId] = resourceStatuses
+		}
+		for _, row := range run.Rows {
+			resourceStatuses[row.Resource] = row.Status
+		}
+	}
+	for _, child := range group.Groups {
+		b.addGroup(child)
+	}
+}
+
+// Diff returns the baseline diff status of a result row
+func (b *Baseline) Diff(controlId string, row *ResultRow) string {
+	previousStatus, ok := b.statuses[controlId][row.Resource]
+	if !ok {
+		return constants.ControlDiffNew
+	}
+	wasFailed := isFailedStatus(previousStatus)
+	isFailed := isFailedStatus(row.Status)
+	switch {
+	case wasFailed && !isFailed:
+		return constants.ControlDiffFixed
+	case !wasFailed && isFailed:
+		return constants.ControlDiffRegressed
+	default:
+		return constants.ControlDiffUnchanged
+	}
+}
+
+func isFailedStatus(status string) bool {
+	return status == constants.ControlAlarm || status == constants.ControlError
+}
+
+// DiffSummary is a struct containing the counts of each baseline diff status
+type DiffSummary struct {
+	New       int `json:"new"`
+	Fixed     int `json:"fixed"`
+	Regressed int `json:"regressed"`
+	Unchanged int `json:"unchanged"`
+}
+
+func (s *DiffSummary) add(diff string) {
+	switch diff {
+	case constants.ControlDiffNew:
+		s.New++
+	case constants.ControlDiffFixed:
+		s.Fixed++
+	case constants.ControlDiffRegressed:
+		s.Regressed++
+	case constants.ControlDiffUnchanged:
+		s.Unchanged++
+	}
+}
+
+func (s *DiffSummary) merge(other *DiffSummary) {
+	s.New += other.New
+	s.Fixed += other.Fixed
+	s.Regressed += other.Regressed
+	s.Unchanged += other.Unchanged
+}
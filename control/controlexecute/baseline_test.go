@@ -0,0 +1,43 @@
+package controlexecute
+
+import (
+	"testing"
+)
+
+type baselineTest struct {
+	controlId string
+	resource  string
+	status    string
+	expected  string
+}
+
+var testCasesBaseline = map[string]baselineTest{
+	"unchanged alarm": {controlId: "control.c1", resource: "r1", status: "alarm", expected: "unchanged"},
+	"fixed":           {controlId: "control.c1", resource: "r2", status: "ok", expected: "fixed"},
+	"regressed":       {controlId: "control.c1", resource: "r3", status: "error", expected: "regressed"},
+	"new resource":    {controlId: "control.c1", resource: "r4", status: "ok", expected: "new"},
+	"new control":     {controlId: "control.c2", resource: "r1", status: "alarm", expected: "new"},
+}
+
+func TestBaseline(t *testing.T) {
+	baseline, err := LoadBaseline("test_data/baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := &ResultGroup{Summary: NewGroupSummary()}
+	for name, test := range testCasesBaseline {
+		row := &ResultRow{Resource: test.resource, Status: test.status}
+		if diff := baseline.Diff(test.controlId, row); diff != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s, got %s", name, test.expected, diff)
+		}
+		root.ControlRuns = append(root.ControlRuns, &ControlRun{ControlId: test.controlId, Rows: []*ResultRow{row}})
+	}
+
+	// now verify the diff summary
+	(&ExecutionTree{Root: root}).ApplyBaseline(baseline)
+	expectedSummary := DiffSummary{New: 2, Fixed: 1, Regressed: 1, Unchanged: 1}
+	if *root.Summary.Diff != expectedSummary {
+		t.Errorf("Test: 'diff summary' FAILED : expected %v, got %v", expectedSummary, *root.Summary.Diff)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/spf13/viper"
 	typehelpers "github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe-plugin-sdk/grpc"
 	"github.com/turbot/steampipe/constants"
@@ -17,7 +18,8 @@ import (
 	"github.com/turbot/steampipe/utils"
 )
 
-const controlQueryTimeout = 240 * time.Second
+// the query timeout used for controls which have no timeout set by the control, a parent benchmark or --query-timeout
+const defaultControlQueryTimeout = 240 * time.Second
 
 type ControlRunStatus uint32
 
@@ -52,6 +54,8 @@ type ControlRun struct {
 	Title       string                  `json:"title"`
 	RowMap      map[string][]*ResultRow `json:"-"`
 	Rows        []*ResultRow            `json:"results"`
+	// the error message, if the control run failed
+	ErrorMessage string `json:"error,omitempty"`
 
 	// the query result stream
 	queryResult *queryresult.Result
@@ -62,6 +66,9 @@ type ControlRun struct {
 	group         *ResultGroup
 	executionTree *ExecutionTree
 	attempts      int
+	// the context used to execute the control query, and its timeout
+	queryContext context.Context
+	queryTimeout time.Duration
 }
 
 func NewControlRun(control *modconfig.Control, group *ResultGroup, executionTree *ExecutionTree) *ControlRun {
@@ -87,6 +94,42 @@ func NewControlRun(control *modconfig.Control, group *ResultGroup, executionTree
 	return res
 }
 
+// reuseResults copies the results of a previous run of the control, and updates the summary of the result group
+func (r *ControlRun) reuseResults(previous *ControlRun) {
+	for _, previousRow := range previous.Rows {
+		row := *previousRow
+		row.Control = r.Control
+		row.BaselineDiff = ""
+		// the exemptions may have changed - restore the alarm status of exempted results and apply the current exemptions
+		if row.Status == constants.ControlExempted {
+			row.Status = constants.ControlAlarm
+			row.ExemptionReason = ""
+		}
+		r.applyExemption(&row)
+		r.addResultRow(&row)
+	}
+	r.createdOrderedResultRows()
+	r.Duration = previous.Duration
+	r.runError = previous.runError
+	r.ErrorMessage = previous.ErrorMessage
+
+	status := ControlRunComplete
+	if r.runError != nil {
+		r.Summary.Error++
+		status = ControlRunError
+	}
+	// setRunStatus is not used, as the run is never executed - so there is no progress to update
+	// and nothing waiting on doneChan - but the status must still be set under the state lock
+	r.stateLock.Lock()
+	r.runStatus = status
+	r.stateLock.Unlock()
+
+	r.group.updateSummary(r.Summary)
+	if len(r.Severity) != 0 {
+		r.group.updateSeverityCounts(r.Severity, r.Summary)
+	}
+}
+
 func (r *ControlRun) Skip() {
 	r.setRunStatus(ControlRunComplete)
 }
@@ -125,6 +168,16 @@ func (r *ControlRun) setSearchPath(ctx context.Context, session *db_common.Datab
 	return err
 }
 
+// setCacheSettings sets the session cache settings for the control
+// the cache TTL defined by the control (or its query) takes precedence over the --cache-ttl arg
+func (r *ControlRun) setCacheSettings(ctx context.Context, session *db_common.DatabaseSession, client db_common.Client) (func(), error) {
+	cache, cacheTTL := r.Control.GetCacheSettings()
+	if ttl := viper.GetInt(constants.ArgCacheTTL); cacheTTL == nil && ttl > 0 {
+		cacheTTL = &ttl
+	}
+	return db_common.SetSessionCacheSettings(ctx, client, session, cache, cacheTTL)
+}
+
 func (r *ControlRun) getCurrentSearchPath(ctx context.Context, session *db_common.DatabaseSession) ([]string, error) {
 	utils.LogTime("ControlRun.getCurrentSearchPath start")
 	defer utils.LogTime("ControlRun.getCurrentSearchPath end")
@@ -205,6 +258,16 @@ func (r *ControlRun) Execute(ctx context.Context, client db_common.Client) {
 	}
 	r.Lifecycle.Add("set_search_path_finish")
 
+	r.Lifecycle.Add("set_cache_settings_start")
+	resetCacheSettings, err := r.setCacheSettings(ctx, dbSession, client)
+	if err != nil {
+		r.SetError(err)
+		return
+	}
+	// reset the cache settings before the session is closed (deferred functions run last-in-first-out)
+	defer resetCacheSettings()
+	r.Lifecycle.Add("set_cache_settings_finish")
+
 	ctxWithDeadline, cancel := r.getControlQueryContext(ctx)
 	// Even though ctx will expire, it is good practice to call its cancellation function in any case.
 	defer cancel()
@@ -247,11 +310,30 @@ func (r *ControlRun) Execute(ctx context.Context, client db_common.Client) {
 
 func (r *ControlRun) getControlQueryContext(ctx context.Context) (context.Context, context.CancelFunc) {
 	// create a context with a deadline
-	shouldBeDoneBy := time.Now().Add(controlQueryTimeout)
+	r.queryTimeout = r.getQueryTimeout()
+	shouldBeDoneBy := time.Now().Add(r.queryTimeout)
 	ctxWithDeadline, cancel := context.WithDeadline(ctx, shouldBeDoneBy)
+	r.queryContext = ctxWithDeadline
 	return ctxWithDeadline, cancel
 }
 
+// getQueryTimeout returns the query timeout for the control
+// --query-timeout takes precedence, followed by the control timeout, then the timeout of the nearest parent benchmark
+func (r *ControlRun) getQueryTimeout() time.Duration {
+	if timeout := viper.GetInt(constants.ArgQueryTimeout); timeout > 0 {
+		return time.Duration(timeout) * time.Second
+	}
+	if r.Control.Timeout != nil {
+		return time.Duration(*r.Control.Timeout) * time.Second
+	}
+	for group := r.group; group != nil; group = group.Parent {
+		if benchmark, ok := group.GroupItem.(*modconfig.Benchmark); ok && benchmark.Timeout != nil {
+			return time.Duration(*benchmark.Timeout) * time.Second
+		}
+	}
+	return defaultControlQueryTimeout
+}
+
 func (r *ControlRun) resolveControlQuery(err error, control *modconfig.Control) (string, error) {
 	query, err := r.executionTree.workspace.ResolveControlQuery(control)
 	if err != nil {
@@ -308,6 +390,7 @@ func (r *ControlRun) gatherResults() {
 				r.SetError(err)
 				return
 			}
+			r.applyExemption(result)
 			r.addResultRow(result)
 		case <-r.doneChan:
 			return
@@ -315,6 +398,14 @@ func (r *ControlRun) gatherResults() {
 	}
 }
 
+// if the result is an alarm for an exempted resource, accept it
+func (r *ControlRun) applyExemption(row *ResultRow) {
+	if exemption := r.executionTree.exemptionForResult(r.Control, row); exemption != nil {
+		row.Status = constants.ControlExempted
+		row.ExemptionReason = exemption.Reason
+	}
+}
+
 // add the result row to our results and update the summary with the row status
 func (r *ControlRun) addResultRow(row *ResultRow) {
 	// update results
@@ -332,12 +423,14 @@ func (r *ControlRun) addResultRow(row *ResultRow) {
 		r.Summary.Info++
 	case constants.ControlError:
 		r.Summary.Error++
+	case constants.ControlExempted:
+		r.Summary.Exempted++
 	}
 }
 
 // populate ordered list of rows
 func (r *ControlRun) createdOrderedResultRows() {
-	statusOrder := []string{constants.ControlError, constants.ControlAlarm, constants.ControlInfo, constants.ControlOk, constants.ControlSkip}
+	statusOrder := []string{constants.ControlError, constants.ControlAlarm, constants.ControlInfo, constants.ControlOk, constants.ControlExempted, constants.ControlSkip}
 	for _, status := range statusOrder {
 		r.Rows = append(r.Rows, r.RowMap[status]...)
 	}
@@ -347,7 +440,12 @@ func (r *ControlRun) SetError(err error) {
 	if err == nil {
 		return
 	}
+	// if the control query timed out, replace the (context or database) error with one naming the timeout
+	if r.queryContext != nil && r.queryContext.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("control timed out after %s - the timeout may be increased with the control or benchmark 'timeout' property, or --%s", r.queryTimeout, constants.ArgQueryTimeout)
+	}
 	r.runError = utils.TransformErrorToSteampipe(err)
+	r.ErrorMessage = r.runError.Error()
 
 	// update error count
 	r.Summary.Error++
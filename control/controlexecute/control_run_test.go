@@ -0,0 +1,63 @@
+package controlexecute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/utils"
+)
+
+type queryTimeoutTest struct {
+	controlTimeout *int
+	// timeouts of the parent benchmarks, nearest first
+	benchmarkTimeouts []*int
+	queryTimeoutArg   int
+	expected          time.Duration
+}
+
+var testCasesQueryTimeout = map[string]queryTimeoutTest{
+	"default": {
+		benchmarkTimeouts: []*int{nil, nil},
+		expected:          defaultControlQueryTimeout,
+	},
+	"control timeout": {
+		controlTimeout:    utils.ToIntegerPointer(600),
+		benchmarkTimeouts: []*int{utils.ToIntegerPointer(30)},
+		expected:          600 * time.Second,
+	},
+	"parent benchmark timeout": {
+		benchmarkTimeouts: []*int{utils.ToIntegerPointer(30), utils.ToIntegerPointer(60)},
+		expected:          30 * time.Second,
+	},
+	"inherited benchmark timeout": {
+		benchmarkTimeouts: []*int{nil, utils.ToIntegerPointer(60)},
+		expected:          60 * time.Second,
+	},
+	"query timeout arg": {
+		controlTimeout:    utils.ToIntegerPointer(600),
+		benchmarkTimeouts: []*int{utils.ToIntegerPointer(30)},
+		queryTimeoutArg:   10,
+		expected:          10 * time.Second,
+	},
+}
+
+func TestQueryTimeout(t *testing.T) {
+	defer viper.Set(constants.ArgQueryTimeout, 0)
+	for name, test := range testCasesQueryTimeout {
+		viper.Set(constants.ArgQueryTimeout, test.queryTimeoutArg)
+
+		// build the group hierarchy, from the root down to the parent of the control
+		group := &ResultGroup{GroupId: RootResultGroupName}
+		for i := len(test.benchmarkTimeouts) - 1; i >= 0; i-- {
+			group = &ResultGroup{Parent: group, GroupItem: &modconfig.Benchmark{Timeout: test.benchmarkTimeouts[i]}}
+		}
+		run := &ControlRun{Control: &modconfig.Control{Timeout: test.controlTimeout}, group: group}
+
+		if timeout := run.getQueryTimeout(); timeout != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s, got %s", name, test.expected, timeout)
+		}
+	}
+}
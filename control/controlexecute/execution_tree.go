@@ -27,11 +27,15 @@ type ExecutionTree struct {
 	client    db_common.Client
 	// an optional map of control names used to filter the controls which are run
 	controlNameFilterMap map[string]bool
-	progress             *ControlProgressRenderer
+	// an optional map of the names of the controls in the shard being run (if '--shard' was passed)
+	shardControlNameMap map[string]bool
+	progress            *ControlProgressRenderer
 	// map of dimension property name to property value to color map
 	DimensionColorGenerator *DimensionColorGenerator
 	// flat list of all control runs
 	controlRuns []*ControlRun
+	// exemptions defined by the workspace mod
+	exemptions []*modconfig.Exemption
 }
 
 func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, client db_common.Client, arg string) (*ExecutionTree, error) {
@@ -40,6 +44,9 @@ func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, clien
 		workspace: workspace,
 		client:    client,
 	}
+	if workspace.Mod != nil {
+		executionTree.exemptions = workspace.Mod.Exemptions
+	}
 	// if a "--where" or "--tag" parameter was passed, build a map of control manes used to filter the controls to run
 	// NOTE: not enabled yet
 	err := executionTree.populateControlFilterMap(ctx)
@@ -57,6 +64,15 @@ func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, clien
 	// build tree of result groups, starting with a synthetic 'root' node
 	executionTree.Root = NewRootResultGroup(executionTree, rootItems...)
 
+	// if a "--shard" parameter was passed, rebuild the tree including only the controls in the shard
+	if shardArg := viper.GetString(constants.ArgShard); shardArg != "" {
+		shard, err := ParseShard(shardArg)
+		if err != nil {
+			return nil, err
+		}
+		executionTree.applyShard(shard, rootItems)
+	}
+
 	// after tree has built, ControlCount will be set - create progress rendered
 	executionTree.progress = NewControlProgressRenderer(len(executionTree.controlRuns))
 
@@ -67,7 +83,7 @@ func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, clien
 // if so, creates a ControlRun, which is added to the parent group
 func (e *ExecutionTree) AddControl(control *modconfig.Control, group *ResultGroup) {
 	// note we use short name to determine whether to include a control
-	if e.ShouldIncludeControl(control.ShortName) {
+	if e.ShouldIncludeControl(control.ShortName) && e.isInShard(control) {
 		// create new ControlRun with treeItem as the parent
 		controlRun := NewControlRun(control, group, e)
 		// add it into the group
@@ -77,6 +93,29 @@ func (e *ExecutionTree) AddControl(control *modconfig.Control, group *ResultGrou
 	}
 }
 
+// applyShard rebuilds the tree, including only the controls which belong to the given shard
+// the shard is selected from all controls in the (filtered) tree, so the tree must already have been built
+func (e *ExecutionTree) applyShard(shard *Shard, rootItems []modconfig.ModTreeItem) {
+	var controlNames []string
+	for _, run := range e.controlRuns {
+		controlNames = append(controlNames, run.Control.Name())
+	}
+	e.shardControlNameMap = shard.selectControls(controlNames)
+	log.Printf("[TRACE] shard %s includes %d controls", shard, len(e.shardControlNameMap))
+
+	e.controlRuns = nil
+	e.Root = NewRootResultGroup(e, rootItems...)
+	// nested benchmarks with no controls in the shard are already excluded - remove any such top level benchmarks
+	e.Root.removeEmptyGroups()
+}
+
+func (e *ExecutionTree) isInShard(control *modconfig.Control) bool {
+	if e.shardControlNameMap == nil {
+		return true
+	}
+	return e.shardControlNameMap[control.Name()]
+}
+
 func (e *ExecutionTree) Execute(ctx context.Context, client db_common.Client) int {
 	log.Println("[TRACE]", "begin ExecutionTree.Execute")
 	defer log.Println("[TRACE]", "end ExecutionTree.Execute")
@@ -111,6 +150,66 @@ func (e *ExecutionTree) Execute(ctx context.Context, client db_common.Client) in
 	return failures
 }
 
+// ReusePreviousResults copies the results of a previous run of the tree for all controls which are not in changedControls,
+// so that only new and changed controls are executed (this is used to re-run the tree when the workspace changes)
+// the current exemptions are re-applied to the reused results, as the exemptions may have changed
+// it returns the number of control runs whose results were reused
+func (e *ExecutionTree) ReusePreviousResults(previous *ExecutionTree, changedControls map[string]bool) int {
+	if previous == nil {
+		return 0
+	}
+	previousRuns := make(map[string]*ControlRun, len(previous.controlRuns))
+	for _, run := range previous.controlRuns {
+		previousRuns[run.Control.Name()] = run
+	}
+
+	reused := 0
+	for _, run := range e.controlRuns {
+		previousRun, ok := previousRuns[run.Control.Name()]
+		// do not reuse results which were not completed (e.g. if the previous run was cancelled)
+		if !ok || changedControls[run.Control.Name()] || !previousRun.Finished() {
+			continue
+		}
+		run.reuseResults(previousRun)
+		reused++
+	}
+
+	// only the remaining control runs will be executed
+	e.progress = NewControlProgressRenderer(len(e.controlRuns) - reused)
+	return reused
+}
+
+// exemptionForResult returns the first unexpired exemption which applies to an alarm result of the control
+// exemptions may reference the control by name, or by name qualified with its mod name
+func (e *ExecutionTree) exemptionForResult(control *modconfig.Control, row *ResultRow) *modconfig.Exemption {
+	if row.Status != constants.ControlAlarm || len(e.exemptions) == 0 {
+		return nil
+	}
+	controlNames := []string{control.Name()}
+	// QualifiedName requires the resource metadata, which is set when the control is parsed from a mod
+	if control.GetMetadata() != nil {
+		controlNames = append(controlNames, control.QualifiedName())
+	}
+	now := time.Now()
+	for _, exemption := range e.exemptions {
+		if exemption.Matches(controlNames, row.Resource, now) {
+			return exemption
+		}
+	}
+	return nil
+}
+
+// ApplyBaseline compares the results of this execution with a baseline run,
+// annotating each result row with its diff status and populating the diff summaries of the result groups
+func (e *ExecutionTree) ApplyBaseline(baseline *Baseline) {
+	e.Root.applyBaseline(baseline)
+}
+
+// HasBaseline returns whether the results have been compared with a baseline run
+func (e *ExecutionTree) HasBaseline() bool {
+	return e.Root.Summary.Diff != nil
+}
+
 func (e *ExecutionTree) populateControlFilterMap(ctx context.Context) error {
 	// if both '--where' and '--tag' have been used, then it's an error
 	if viper.IsSet(constants.ArgWhere) && viper.IsSet(constants.ArgTag) {
@@ -0,0 +1,155 @@
+package controlexecute
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// build an execution tree with a single group containing a run for each of the given controls
+func newTestExecutionTree(controlNames ...string) *ExecutionTree {
+	tree := &ExecutionTree{}
+	tree.Root = &ResultGroup{GroupId: RootResultGroupName, Summary: NewGroupSummary(), Severity: make(map[string]StatusSummary), summaryUpdateLock: new(sync.Mutex)}
+	for _, name := range controlNames {
+		run := NewControlRun(&modconfig.Control{FullName: name}, tree.Root, tree)
+		tree.Root.ControlRuns = append(tree.Root.ControlRuns, run)
+		tree.controlRuns = append(tree.controlRuns, run)
+	}
+	return tree
+}
+
+func TestReusePreviousResults(t *testing.T) {
+	previous := newTestExecutionTree("control.unchanged", "control.changed", "control.errored")
+	previous.controlRuns[0].Rows = []*ResultRow{{Status: "alarm", Resource: "r1"}, {Status: "ok", Resource: "r2"}}
+	previous.controlRuns[0].runStatus = ControlRunComplete
+	previous.controlRuns[1].Rows = []*ResultRow{{Status: "ok", Resource: "r1"}}
+	previous.controlRuns[1].runStatus = ControlRunComplete
+	previous.controlRuns[2].runError = errors.New("failed")
+	previous.controlRuns[2].runStatus = ControlRunError
+
+	tree := newTestExecutionTree("control.unchanged", "control.changed", "control.errored", "control.new")
+	reused := tree.ReusePreviousResults(previous, map[string]bool{"control.changed": true})
+
+	if reused != 2 {
+		t.Errorf("Test: 'reused count'' FAILED : expected 2, got %d", reused)
+	}
+	expectedFinished := map[string]bool{"control.unchanged": true, "control.changed": false, "control.errored": true, "control.new": false}
+	for _, run := range tree.controlRuns {
+		if run.Finished() != expectedFinished[run.Control.Name()] {
+			t.Errorf("Test: 'reuse %s'' FAILED : expected finished %v, got %v", run.Control.Name(), expectedFinished[run.Control.Name()], run.Finished())
+		}
+	}
+	if rows := tree.controlRuns[0].Rows; len(rows) != 2 || rows[0].Control != tree.controlRuns[0].Control {
+		t.Errorf("Test: 'reuse rows'' FAILED : expected 2 rows referencing the new control")
+	}
+	if tree.controlRuns[2].GetError() == nil {
+		t.Errorf("Test: 'reuse error'' FAILED : expected the error to be reused")
+	}
+	expectedSummary := StatusSummary{Alarm: 1, Ok: 1, Error: 1}
+	if tree.Root.Summary.Status != expectedSummary {
+		t.Errorf("Test: 'reuse summary'' FAILED : expected %v, got %v", expectedSummary, tree.Root.Summary.Status)
+	}
+	if tree.progress.total != 2 {
+		t.Errorf("Test: 'reuse progress'' FAILED : expected 2 controls to execute, got %d", tree.progress.total)
+	}
+}
+
+type exemptionForResultTest struct {
+	exemptionControl string
+	status           string
+	resource         string
+	expectExemption  bool
+}
+
+var testCasesExemptionForResult = map[string]exemptionForResultTest{
+	"control name": {
+		exemptionControl: "control.c1",
+		status:           constants.ControlAlarm,
+		resource:         "r1",
+		expectExemption:  true,
+	},
+	"qualified control name": {
+		exemptionControl: "m1.control.c1",
+		status:           constants.ControlAlarm,
+		resource:         "r1",
+		expectExemption:  true,
+	},
+	"other mod": {
+		exemptionControl: "m2.control.c1",
+		status:           constants.ControlAlarm,
+		resource:         "r1",
+		expectExemption:  false,
+	},
+	"other resource": {
+		exemptionControl: "control.c1",
+		status:           constants.ControlAlarm,
+		resource:         "r2",
+		expectExemption:  false,
+	},
+	"ok result": {
+		exemptionControl: "control.c1",
+		status:           constants.ControlOk,
+		resource:         "r1",
+		expectExemption:  false,
+	},
+}
+
+func TestExemptionForResult(t *testing.T) {
+	control := &modconfig.Control{FullName: "control.c1"}
+	control.SetMetadata(&modconfig.ResourceMetadata{ModName: "m1"})
+
+	for name, test := range testCasesExemptionForResult {
+		exemption := &modconfig.Exemption{Control: test.exemptionControl, Resource: "r1", Reason: "accepted"}
+		if diags := exemption.Initialise(hcl.Range{}); diags.HasErrors() {
+			t.Fatalf("Test: '%s'' FAILED : invalid exemption: %s", name, diags.Error())
+		}
+		tree := &ExecutionTree{exemptions: []*modconfig.Exemption{exemption}}
+
+		result := tree.exemptionForResult(control, &ResultRow{Status: test.status, Resource: test.resource})
+		if (result != nil) != test.expectExemption {
+			t.Errorf("Test: '%s'' FAILED : expected exemption %v, got %v", name, test.expectExemption, result != nil)
+		}
+	}
+}
+
+func TestReusePreviousResultsReappliesExemptions(t *testing.T) {
+	previous := newTestExecutionTree("control.c1")
+	previous.controlRuns[0].Rows = []*ResultRow{
+		{Status: constants.ControlExempted, Resource: "r1", ExemptionReason: "removed exemption"},
+		{Status: constants.ControlAlarm, Resource: "r2"},
+		{Status: constants.ControlOk, Resource: "r3"},
+	}
+	previous.controlRuns[0].runStatus = ControlRunComplete
+
+	// the exemption for r1 has been removed and an exemption for r2 added
+	exemption := &modconfig.Exemption{Control: "control.c1", Resource: "r2", Reason: "new exemption"}
+	if diags := exemption.Initialise(hcl.Range{}); diags.HasErrors() {
+		t.Fatalf("invalid exemption: %s", diags.Error())
+	}
+	tree := newTestExecutionTree("control.c1")
+	tree.exemptions = []*modconfig.Exemption{exemption}
+	tree.ReusePreviousResults(previous, nil)
+
+	expectedStatus := map[string]string{"r1": constants.ControlAlarm, "r2": constants.ControlExempted, "r3": constants.ControlOk}
+	expectedReason := map[string]string{"r1": "", "r2": "new exemption", "r3": ""}
+	for _, row := range tree.controlRuns[0].Rows {
+		if row.Status != expectedStatus[row.Resource] {
+			t.Errorf("Test: 'reuse status %s'' FAILED : expected %s, got %s", row.Resource, expectedStatus[row.Resource], row.Status)
+		}
+		if row.ExemptionReason != expectedReason[row.Resource] {
+			t.Errorf("Test: 'reuse exemption reason %s'' FAILED : expected %q, got %q", row.Resource, expectedReason[row.Resource], row.ExemptionReason)
+		}
+	}
+	// the previous results must not be modified
+	if previous.controlRuns[0].Rows[0].Status != constants.ControlExempted {
+		t.Errorf("Test: 'reuse previous rows'' FAILED : expected the previous results to be unchanged")
+	}
+	expectedSummary := StatusSummary{Alarm: 1, Ok: 1, Exempted: 1}
+	if tree.Root.Summary.Status != expectedSummary {
+		t.Errorf("Test: 'reuse summary'' FAILED : expected %v, got %v", expectedSummary, tree.Root.Summary.Status)
+	}
+}
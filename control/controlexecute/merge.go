@@ -0,0 +1,212 @@
+package controlexecute
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// MergeResults builds an ExecutionTree from the results of one or more check runs, exported as JSON
+// (e.g. the results of the shards of a check run, run with '--shard')
+// groups with the same id are merged, and the merged tree may be displayed and exported as if it came from a single run
+func MergeResults(paths []string) (*ExecutionTree, error) {
+	executionTree := &ExecutionTree{
+		// the exports do not include the time of the run, so use the time of the merge
+		StartTime: time.Now(),
+	}
+	executionTree.EndTime = executionTree.StartTime
+
+	root := &ResultGroup{
+		GroupId: RootResultGroupName,
+		Groups:  []*ResultGroup{},
+		Tags:    make(map[string]string),
+	}
+	for _, path := range paths {
+		exportedRoot, err := readResultExport(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load check results %s: %s", path, err.Error())
+		}
+		mergeResultGroup(root, exportedRoot)
+	}
+
+	// now rebuild the control tree items and summaries of the merged groups
+	executionTree.Root = root
+	executionTree.initialiseMergedGroup(root, nil)
+
+	// build map of dimension property name to property value to color map
+	executionTree.DimensionColorGenerator, _ = NewDimensionColorGenerator(4, 27)
+	executionTree.DimensionColorGenerator.populate(executionTree)
+
+	return executionTree, nil
+}
+
+// mergeResultGroup merges the control runs and child groups of source into target
+// items which are not already in target are inserted after the preceding item of source,
+// so the order of the items in each export is preserved
+// if a control appears in the same group of more than one export, the first result is used
+func mergeResultGroup(target, source *ResultGroup) {
+	sourceRunIds := make([]string, len(source.ControlRuns))
+	for i, run := range source.ControlRuns {
+		sourceRunIds[i] = run.ControlId
+	}
+	runIndex := func(id string) int { return controlRunIndex(target.ControlRuns, id) }
+	for i, run := range source.ControlRuns {
+		if runIndex(run.ControlId) != -1 {
+			continue
+		}
+		insertAt := mergeInsertionIndex(runIndex, sourceRunIds, i, len(target.ControlRuns))
+		target.ControlRuns = append(target.ControlRuns[:insertAt], append([]*ControlRun{run}, target.ControlRuns[insertAt:]...)...)
+	}
+
+	sourceGroupIds := make([]string, len(source.Groups))
+	for i, group := range source.Groups {
+		sourceGroupIds[i] = group.GroupId
+	}
+	groupIndex := func(id string) int { return resultGroupIndex(target.Groups, id) }
+	for i, sourceChild := range source.Groups {
+		if idx := groupIndex(sourceChild.GroupId); idx != -1 {
+			mergeResultGroup(target.Groups[idx], sourceChild)
+			continue
+		}
+		targetChild := &ResultGroup{
+			GroupId:     sourceChild.GroupId,
+			Title:       sourceChild.Title,
+			Description: sourceChild.Description,
+			Tags:        sourceChild.Tags,
+			Groups:      []*ResultGroup{},
+		}
+		mergeResultGroup(targetChild, sourceChild)
+		insertAt := mergeInsertionIndex(groupIndex, sourceGroupIds, i, len(target.Groups))
+		target.Groups = append(target.Groups[:insertAt], append([]*ResultGroup{targetChild}, target.Groups[insertAt:]...)...)
+	}
+}
+
+// mergeInsertionIndex returns the position in the target list at which the source item with index i should be inserted
+// - after the preceding source item (which will already have been merged)
+// - or for the first source item, before the first following source item which is in the target list
+// - otherwise at the end of the target list
+func mergeInsertionIndex(targetIndex func(string) int, sourceIds []string, i int, targetLen int) int {
+	if i > 0 {
+		return targetIndex(sourceIds[i-1]) + 1
+	}
+	for _, id := range sourceIds[1:] {
+		if idx := targetIndex(id); idx != -1 {
+			return idx
+		}
+	}
+	return targetLen
+}
+
+func controlRunIndex(runs []*ControlRun, controlId string) int {
+	for i, run := range runs {
+		if run.ControlId == controlId {
+			return i
+		}
+	}
+	return -1
+}
+
+func resultGroupIndex(groups []*ResultGroup, groupId string) int {
+	for i, group := range groups {
+		if group.GroupId == groupId {
+			return i
+		}
+	}
+	return -1
+}
+
+// initialiseMergedGroup populates the properties of a merged group which are not exported,
+// building a benchmark to act as the group item, and recalculating the summaries from the results
+func (e *ExecutionTree) initialiseMergedGroup(group *ResultGroup, parent *ResultGroup) {
+	group.Parent = parent
+	group.Summary = NewGroupSummary()
+	group.Severity = make(map[string]StatusSummary)
+	group.summaryUpdateLock = new(sync.Mutex)
+
+	var children []modconfig.ModTreeItem
+	for _, run := range group.ControlRuns {
+		e.initialiseMergedControlRun(run, group)
+		children = append(children, run.Control)
+	}
+	for _, child := range group.Groups {
+		e.initialiseMergedGroup(child, group)
+		children = append(children, child.GroupItem)
+	}
+
+	// the root group has no group item
+	if parent != nil {
+		title := group.Title
+		description := group.Description
+		tags := group.Tags
+		benchmark := &modconfig.Benchmark{
+			FullName:    group.GroupId,
+			Title:       &title,
+			Description: &description,
+			Tags:        &tags,
+		}
+		benchmark.SetChildren(children)
+		group.GroupItem = benchmark
+	}
+
+	// if the results were compared with a baseline, rebuild the diff summary
+	diffSummary := &DiffSummary{}
+	hasBaseline := false
+	for _, run := range group.ControlRuns {
+		for _, row := range run.Rows {
+			if row.BaselineDiff != "" {
+				hasBaseline = true
+				diffSummary.add(row.BaselineDiff)
+			}
+		}
+	}
+	for _, child := range group.Groups {
+		if child.Summary.Diff != nil {
+			hasBaseline = true
+			diffSummary.merge(child.Summary.Diff)
+		}
+	}
+	if hasBaseline {
+		group.Summary.Diff = diffSummary
+	}
+}
+
+// initialiseMergedControlRun populates the properties of a merged control run which are not exported,
+// building a control from the exported control properties and recalculating the summary from the results
+func (e *ExecutionTree) initialiseMergedControlRun(run *ControlRun, group *ResultGroup) {
+	title := run.Title
+	description := run.Description
+	severity := run.Severity
+	tags := run.Tags
+	run.Control = &modconfig.Control{
+		ShortName:   run.ControlId,
+		FullName:    run.ControlId,
+		Title:       &title,
+		Description: &description,
+		Severity:    &severity,
+		Tags:        &tags,
+	}
+	if parsedName, err := modconfig.ParseResourceName(run.ControlId); err == nil {
+		run.Control.ShortName = parsedName.Name
+	}
+
+	run.group = group
+	run.executionTree = e
+	run.RowMap = make(map[string][]*ResultRow)
+	run.runStatus = ControlRunComplete
+	for _, row := range run.Rows {
+		row.Control = run.Control
+		run.addResultRow(row)
+	}
+	if run.ErrorMessage != "" {
+		run.runError = errors.New(run.ErrorMessage)
+		run.runStatus = ControlRunError
+		run.Summary.Error++
+	}
+	group.updateSummary(run.Summary)
+	group.updateSeverityCounts(run.Severity, run.Summary)
+
+	e.controlRuns = append(e.controlRuns, run)
+}
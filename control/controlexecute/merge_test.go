@@ -0,0 +1,70 @@
+package controlexecute
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeResults(t *testing.T) {
+	tree, err := MergeResults([]string{"test_data/merge_shard1.json", "test_data/merge_shard2.json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the groups of both exports should be merged
+	if len(tree.Root.Groups) != 1 {
+		t.Fatalf("Test: 'merge groups'' FAILED : expected 1 root group, got %d", len(tree.Root.Groups))
+	}
+	b1 := tree.Root.GetChildGroupByName("benchmark.b1")
+	if b1 == nil || len(b1.ControlRuns) != 2 {
+		t.Fatalf("Test: 'merge controls'' FAILED : expected benchmark.b1 to have 2 controls")
+	}
+	if b1.GetControlRunByName("control.c1") == nil || b1.GetControlRunByName("control.c2") == nil {
+		t.Errorf("Test: 'merge controls'' FAILED : expected benchmark.b1 to contain control.c1 and control.c2")
+	}
+	if b2 := tree.Root.GetChildGroupByName("benchmark.b2"); b2 == nil || b2.Parent != tree.Root.Groups[0] {
+		t.Errorf("Test: 'merge groups'' FAILED : expected benchmark.b2 to be a child of mod.m1")
+	}
+
+	// the summaries should be rebuilt from the results, including errored controls
+	expectedSummary := StatusSummary{Alarm: 1, Ok: 2, Error: 1}
+	if tree.Root.Summary.Status != expectedSummary {
+		t.Errorf("Test: 'merge summary'' FAILED : expected %v, got %v", expectedSummary, tree.Root.Summary.Status)
+	}
+	expectedSeverity := map[string]StatusSummary{
+		"high": {Alarm: 1, Ok: 1},
+		"":     {Ok: 1, Error: 1},
+	}
+	if !reflect.DeepEqual(tree.Root.Summary.Severity, expectedSeverity) {
+		t.Errorf("Test: 'merge severity summary'' FAILED : expected %v, got %v", expectedSeverity, tree.Root.Summary.Severity)
+	}
+	if run := b1.GetControlRunByName("control.c2"); run.GetError() == nil {
+		t.Errorf("Test: 'merge error'' FAILED : expected control.c2 to have an error")
+	}
+
+	// the group items should list the merged children, for the text renderer
+	if children := b1.GroupItem.GetChildren(); len(children) != 2 {
+		t.Errorf("Test: 'merge group item'' FAILED : expected 2 children, got %d", len(children))
+	}
+	if len(tree.controlRuns) != 3 {
+		t.Errorf("Test: 'merge control runs'' FAILED : expected 3 control runs, got %d", len(tree.controlRuns))
+	}
+}
+
+func TestMergeResultGroupOrder(t *testing.T) {
+	target := &ResultGroup{ControlRuns: []*ControlRun{{ControlId: "c2"}, {ControlId: "c5"}}}
+	source := &ResultGroup{ControlRuns: []*ControlRun{{ControlId: "c1"}, {ControlId: "c2"}, {ControlId: "c3"}, {ControlId: "c4"}, {ControlId: "c5"}, {ControlId: "c6"}}}
+	mergeResultGroup(target, source)
+	// a control in a later export is added after the existing controls
+	mergeResultGroup(target, &ResultGroup{ControlRuns: []*ControlRun{{ControlId: "c7"}}})
+
+	expected := []string{"c1", "c2", "c3", "c4", "c5", "c6", "c7"}
+	if len(target.ControlRuns) != len(expected) {
+		t.Fatalf("Test: 'merge order'' FAILED : expected %d control runs, got %d", len(expected), len(target.ControlRuns))
+	}
+	for i, id := range expected {
+		if target.ControlRuns[i].ControlId != id {
+			t.Errorf("Test: 'merge order'' FAILED : expected %s at position %d, got %s", id, i, target.ControlRuns[i].ControlId)
+		}
+	}
+}
@@ -40,6 +40,8 @@ type ResultGroup struct {
 type GroupSummary struct {
 	Status   StatusSummary            `json:"status"`
 	Severity map[string]StatusSummary `json:"-"`
+	// counts of each baseline diff status - only set if a baseline was specified
+	Diff *DiffSummary `json:"diff,omitempty"`
 }
 
 func NewGroupSummary() *GroupSummary {
@@ -131,6 +133,7 @@ func (r *ResultGroup) updateSummary(summary StatusSummary) {
 	r.Summary.Status.Info += summary.Info
 	r.Summary.Status.Ok += summary.Ok
 	r.Summary.Status.Error += summary.Error
+	r.Summary.Status.Exempted += summary.Exempted
 	if r.Parent != nil {
 		r.Parent.updateSummary(summary)
 	}
@@ -140,7 +143,7 @@ func (r *ResultGroup) updateSeverityCounts(severity string, summary StatusSummar
 	r.summaryUpdateLock.Lock()
 	defer r.summaryUpdateLock.Unlock()
 
-	val, exists := r.Severity[severity]
+	val, exists := r.Summary.Severity[severity]
 	if !exists {
 		val = StatusSummary{}
 	}
@@ -149,6 +152,7 @@ func (r *ResultGroup) updateSeverityCounts(severity string, summary StatusSummar
 	val.Info += summary.Info
 	val.Ok += summary.Ok
 	val.Skip += summary.Skip
+	val.Exempted += summary.Exempted
 
 	r.Summary.Severity[severity] = val
 	if r.Parent != nil {
@@ -163,6 +167,10 @@ func (r *ResultGroup) Execute(ctx context.Context, client db_common.Client, para
 	startTime := time.Now()
 
 	for _, controlRun := range r.ControlRuns {
+		// if the results were reused from a previous run, there is nothing to execute
+		if controlRun.Finished() {
+			continue
+		}
 		if utils.IsContextCancelled(ctx) {
 			controlRun.SetError(ctx.Err())
 			continue
@@ -198,6 +206,23 @@ func (r *ResultGroup) Execute(ctx context.Context, client db_common.Client, para
 	r.Duration = time.Since(startTime)
 }
 
+// applyBaseline sets the baseline diff status of all result rows of this group and its children,
+// and populates the diff summary of each group
+func (r *ResultGroup) applyBaseline(baseline *Baseline) *DiffSummary {
+	diffSummary := &DiffSummary{}
+	for _, run := range r.ControlRuns {
+		for _, row := range run.Rows {
+			row.BaselineDiff = baseline.Diff(run.ControlId, row)
+			diffSummary.add(row.BaselineDiff)
+		}
+	}
+	for _, child := range r.Groups {
+		diffSummary.merge(child.applyBaseline(baseline))
+	}
+	r.Summary.Diff = diffSummary
+	return diffSummary
+}
+
 // GetGroupByName finds an immediate child ResultGroup with a specific name
 func (r *ResultGroup) GetGroupByName(name string) *ResultGroup {
 	for _, group := range r.Groups {
@@ -231,6 +256,17 @@ func (r *ResultGroup) GetControlRunByName(name string) *ControlRun {
 	return nil
 }
 
+// removeEmptyGroups removes the child groups which contain no control runs
+func (r *ResultGroup) removeEmptyGroups() {
+	groups := []*ResultGroup{}
+	for _, g := range r.Groups {
+		if g.ControlRunCount() > 0 {
+			groups = append(groups, g)
+		}
+	}
+	r.Groups = groups
+}
+
 func (r *ResultGroup) ControlRunCount() int {
 	count := len(r.ControlRuns)
 	for _, g := range r.Groups {
@@ -15,11 +15,15 @@ import (
 
 // ResultRow is the result of a control execution for a single resource
 type ResultRow struct {
-	Reason     string             `json:"reason" csv:"reason"`
-	Resource   string             `json:"resource" csv:"resource"`
-	Status     string             `json:"status" csv:"status"`
-	Dimensions []Dimension        `json:"dimensions"`
-	Control    *modconfig.Control `json:"-" csv:"control_id:FullName,control_title:Title,control_description:Description"`
+	Reason     string      `json:"reason" csv:"reason"`
+	Resource   string      `json:"resource" csv:"resource"`
+	Status     string      `json:"status" csv:"status"`
+	Dimensions []Dimension `json:"dimensions"`
+	// how the result has changed since the baseline run (if a baseline was specified)
+	BaselineDiff string `json:"baseline_diff,omitempty"`
+	// the reason given by the exemption which accepted the result (if the status is exempted)
+	ExemptionReason string             `json:"exemption_reason,omitempty"`
+	Control         *modconfig.Control `json:"-" csv:"control_id:FullName,control_title:Title,control_description:Description"`
 }
 
 // AddDimension checks whether a column value is a scalar type, and if so adds it to the Dimensions map
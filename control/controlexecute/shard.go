@@ -0,0 +1,56 @@
+package controlexecute
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Shard is a struct representing one of a number of equally sized subsets of the controls of a check run
+// it is specified in the form '<index>/<count>', e.g. '2/5' (the index is 1-based)
+type Shard struct {
+	Index int
+	Count int
+}
+
+// ParseShard parses a shard string of the form '<index>/<count>'
+func ParseShard(shard string) (*Shard, error) {
+	parts := strings.Split(shard, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid shard '%s' - must be in the form '<index>/<count>', e.g. '2/5'", shard)
+	}
+	index, indexErr := strconv.Atoi(strings.TrimSpace(parts[0]))
+	count, countErr := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if indexErr != nil || countErr != nil || count < 1 || index < 1 || index > count {
+		return nil, fmt.Errorf("invalid shard '%s' - must be in the form '<index>/<count>', where index is between 1 and count", shard)
+	}
+	return &Shard{Index: index, Count: count}, nil
+}
+
+// selectControls returns a map of the names of the controls which belong to this shard
+// the names are sorted and dealt out to the shards in turn, so the selection is deterministic
+// and every control belongs to exactly one shard, however many times it appears in the tree
+func (s *Shard) selectControls(controlNames []string) map[string]bool {
+	nameMap := make(map[string]bool)
+	var sortedNames []string
+	for _, name := range controlNames {
+		if !nameMap[name] {
+			nameMap[name] = true
+			sortedNames = append(sortedNames, name)
+		}
+	}
+	sort.Strings(sortedNames)
+
+	res := make(map[string]bool)
+	for i, name := range sortedNames {
+		if i%s.Count == s.Index-1 {
+			res[name] = true
+		}
+	}
+	return res
+}
+
+func (s *Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
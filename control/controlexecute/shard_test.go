@@ -0,0 +1,90 @@
+package controlexecute
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+type shardTest struct {
+	shard    string
+	expected interface{}
+}
+
+var testCasesParseShard = map[string]shardTest{
+	"valid":          {shard: "2/5", expected: Shard{Index: 2, Count: 5}},
+	"single shard":   {shard: "1/1", expected: Shard{Index: 1, Count: 1}},
+	"index too high": {shard: "6/5", expected: "ERROR"},
+	"zero index":     {shard: "0/5", expected: "ERROR"},
+	"zero count":     {shard: "0/0", expected: "ERROR"},
+	"missing count":  {shard: "2", expected: "ERROR"},
+	"not a number":   {shard: "a/b", expected: "ERROR"},
+}
+
+func TestParseShard(t *testing.T) {
+	for name, test := range testCasesParseShard {
+		shard, err := ParseShard(test.shard)
+		if err != nil {
+			if test.expected != "ERROR" {
+				t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			}
+			continue
+		}
+		if test.expected == "ERROR" {
+			t.Errorf("Test: '%s'' FAILED : expected error but did not get one", name)
+			continue
+		}
+		if *shard != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, *shard)
+		}
+	}
+}
+
+func TestShardSelectControls(t *testing.T) {
+	// controls may appear more than once in the tree, and in any order
+	controlNames := []string{"control.e", "control.b", "control.a", "control.d", "control.c", "control.b"}
+	expected := []map[string]bool{
+		{"control.a": true, "control.d": true},
+		{"control.b": true, "control.e": true},
+		{"control.c": true},
+	}
+	for i, expectedControls := range expected {
+		shard := &Shard{Index: i + 1, Count: len(expected)}
+		if controls := shard.selectControls(controlNames); !reflect.DeepEqual(controls, expectedControls) {
+			t.Errorf("Test: 'shard %s'' FAILED : expected %v, got %v", shard, expectedControls, controls)
+		}
+	}
+}
+
+func TestApplyShardRemovesEmptyGroups(t *testing.T) {
+	newBenchmark := func(name string, children ...modconfig.ModTreeItem) *modconfig.Benchmark {
+		benchmark := &modconfig.Benchmark{FullName: name}
+		benchmark.SetChildren(children)
+		return benchmark
+	}
+	// with 2 shards, shard 1 includes control.a and control.c, shard 2 includes control.b
+	rootItems := []modconfig.ModTreeItem{
+		newBenchmark("benchmark.b1", &modconfig.Control{FullName: "control.a", ShortName: "a"}),
+		newBenchmark("benchmark.b2", newBenchmark("benchmark.b3", &modconfig.Control{FullName: "control.b", ShortName: "b"})),
+		newBenchmark("benchmark.b4", &modconfig.Control{FullName: "control.c", ShortName: "c"}),
+	}
+	expectedGroups := [][]string{
+		{"benchmark.b1", "benchmark.b4"},
+		{"benchmark.b2"},
+	}
+	for i, expected := range expectedGroups {
+		shard := &Shard{Index: i + 1, Count: len(expectedGroups)}
+		tree := &ExecutionTree{}
+		tree.Root = NewRootResultGroup(tree, rootItems...)
+		tree.applyShard(shard, rootItems)
+
+		var groupIds []string
+		for _, group := range tree.Root.Groups {
+			groupIds = append(groupIds, group.GroupId)
+		}
+		if !reflect.DeepEqual(groupIds, expected) {
+			t.Errorf("Test: 'shard %s'' FAILED : expected groups %v, got %v", shard, expected, groupIds)
+		}
+	}
+}
@@ -7,6 +7,8 @@ type StatusSummary struct {
 	Info  int `json:"info"`
 	Skip  int `json:"skip"`
 	Error int `json:"error"`
+	// alarms accepted by an exemption
+	Exempted int `json:"exempted"`
 }
 
 func (s *StatusSummary) FailedCount() int {
@@ -14,5 +16,5 @@ func (s *StatusSummary) FailedCount() int {
 }
 
 func (s *StatusSummary) TotalCount() int {
-	return s.Alarm + s.Ok + s.Info + s.Skip + s.Error
+	return s.Alarm + s.Ok + s.Info + s.Skip + s.Error + s.Exempted
 }
@@ -0,0 +1,145 @@
+package controlexecute
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/db/db_common"
+)
+
+// Store writes the results of the execution into the check history tables,
+// creating the tables if they do not exist
+// a single run row is written for the execution, with a result row for every control result -
+// these are written in a single transaction, so the results of a run are stored atomically
+func (e *ExecutionTree) Store(ctx context.Context, client db_common.Client, target string, args []string) (err error) {
+	var modName, modVersion string
+	if mod := e.workspace.Mod; mod != nil {
+		modName = mod.ShortName
+		modVersion = typehelpers.SafeString(mod.Version)
+	}
+	runValues, err := getCheckRunValues(e.StartTime, e.EndTime, target, args, modName, modVersion)
+	if err != nil {
+		return err
+	}
+	resultValues, err := getCheckResultValues(e.controlRuns)
+	if err != nil {
+		return err
+	}
+
+	session, err := client.AcquireSession(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to store check results: %v", err)
+	}
+	defer session.Close()
+
+	tx, err := session.Connection.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to store check results: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			err = fmt.Errorf("failed to store check results: %v", err)
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, getCheckHistoryTablesCreateSql()); err != nil {
+		return err
+	}
+	// all values are passed as query parameters, so no escaping is needed
+	var runId int64
+	if err = tx.QueryRowContext(ctx, getCheckRunInsertSql(), runValues...).Scan(&runId); err != nil {
+		return err
+	}
+	if err = copyCheckResults(ctx, tx, runId, resultValues); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func getCheckHistoryTablesCreateSql() string {
+	return fmt.Sprintf(`create table if not exists public.%[1]s (
+  run_id bigserial primary key,
+  start_time timestamptz not null,
+  end_time timestamptz not null,
+  target text,
+  args jsonb,
+  mod_name text,
+  mod_version text
+);
+create table if not exists public.%[2]s (
+  run_id bigint not null references public.%[1]s(run_id) on delete cascade,
+  control_id text not null,
+  status text not null,
+  reason text,
+  resource text,
+  dimensions jsonb
+);
+create index if not exists %[2]s_run_id_idx on public.%[2]s(run_id);`, constants.CheckHistoryTableRun, constants.CheckHistoryTableResult)
+}
+
+// getCheckRunInsertSql returns the parameterised statement which inserts the run row, returning the run id
+func getCheckRunInsertSql() string {
+	return fmt.Sprintf(`insert into public.%s (start_time, end_time, target, args, mod_name, mod_version) values ($1, $2, $3, $4::jsonb, $5, $6) returning run_id`,
+		constants.CheckHistoryTableRun)
+}
+
+// getCheckRunValues returns the query parameters for the run insert statement
+func getCheckRunValues(startTime, endTime time.Time, target string, args []string, modName, modVersion string) ([]interface{}, error) {
+	argsJson, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{startTime, endTime, target, string(argsJson), modName, modVersion}, nil
+}
+
+// getCheckResultValues returns the control_id, status, reason, resource and dimensions values of every result row
+func getCheckResultValues(controlRuns []*ControlRun) ([][]interface{}, error) {
+	var res [][]interface{}
+	for _, run := range controlRuns {
+		for _, row := range run.Rows {
+			dimensionsJson, err := json.Marshal(dimensionsMap(row.Dimensions))
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, []interface{}{run.ControlId, row.Status, row.Reason, row.Resource, string(dimensionsJson)})
+		}
+	}
+	return res, nil
+}
+
+// copyCheckResults copies the result rows for the given run into the result table
+func copyCheckResults(ctx context.Context, tx *sql.Tx, runId int64, resultValues [][]interface{}) error {
+	// if there are no results, there is nothing to copy
+	if len(resultValues) == 0 {
+		return nil
+	}
+	stmt, err := tx.PrepareContext(ctx, pq.CopyInSchema("public", constants.CheckHistoryTableResult, "run_id", "control_id", "status", "reason", "resource", "dimensions"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, values := range resultValues {
+		if _, err := stmt.ExecContext(ctx, append([]interface{}{runId}, values...)...); err != nil {
+			return err
+		}
+	}
+	// an exec with no arguments flushes the copy
+	_, err = stmt.ExecContext(ctx)
+	return err
+}
+
+func dimensionsMap(dimensions []Dimension) map[string]string {
+	res := make(map[string]string, len(dimensions))
+	for _, d := range dimensions {
+		res[d.Key] = d.Value
+	}
+	return res
+}
@@ -0,0 +1,64 @@
+package controlexecute
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type storeTest struct {
+	controlRuns []*ControlRun
+	expected    [][]interface{}
+}
+
+var testCasesStore = map[string]storeTest{
+	"no results": {
+		controlRuns: []*ControlRun{{ControlId: "control.c1"}},
+	},
+	"results": {
+		controlRuns: []*ControlRun{
+			{ControlId: "control.c1", Rows: []*ResultRow{
+				{Status: "ok", Reason: "it's fine", Resource: "r1", Dimensions: []Dimension{{Key: "region", Value: "us-east-1"}}},
+				{Status: "alarm", Reason: "bad", Resource: "r2"},
+			}},
+		},
+		expected: [][]interface{}{
+			{"control.c1", "ok", "it's fine", "r1", `{"region":"us-east-1"}`},
+			{"control.c1", "alarm", "bad", "r2", "{}"},
+		},
+	},
+	"results containing sql": {
+		controlRuns: []*ControlRun{
+			{ControlId: "control.c1", Rows: []*ResultRow{
+				{Status: "ok", Reason: "$steampipe_escape$); drop table public.steampipe_check_run; --", Resource: "r1"},
+			}},
+		},
+		// values are passed as query parameters, so are stored unchanged
+		expected: [][]interface{}{
+			{"control.c1", "ok", "$steampipe_escape$); drop table public.steampipe_check_run; --", "r1", "{}"},
+		},
+	},
+}
+
+func TestCheckResultValues(t *testing.T) {
+	for name, test := range testCasesStore {
+		values, err := getCheckResultValues(test.controlRuns)
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(values, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %v, \ngot:\n %v\n", name, test.expected, values)
+		}
+	}
+}
+
+func TestCheckRunInsertSql(t *testing.T) {
+	sql := getCheckRunInsertSql()
+	if !strings.Contains(sql, "insert into public.steampipe_check_run") || !strings.Contains(sql, "returning run_id") {
+		t.Errorf("Test: 'run insert sql'' FAILED : unexpected sql %s", sql)
+	}
+	if strings.Count(sql, "$") != 6 {
+		t.Errorf("Test: 'run insert sql'' FAILED : expected 6 parameters, got %s", sql)
+	}
+}
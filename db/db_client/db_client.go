@@ -22,6 +22,7 @@ type DbClient struct {
 	ensureSessionFunc         db_common.EnsureSessionStateCallback
 	dbClient                  *sql.DB
 	schemaMetadata            *schema.Metadata
+	connectionMap             *steampipeconfig.ConnectionDataMap
 	requiredSessionSearchPath []string
 
 	// concurrency management for db session access
@@ -48,6 +49,7 @@ func NewDbClient(connectionString string) (*DbClient, error) {
 		dbClient: db,
 		// set up a blank struct for the schema metadata
 		schemaMetadata: schema.NewMetadata(),
+		connectionMap:  &steampipeconfig.ConnectionDataMap{},
 		// a waitgroup to keep track of active session initializations
 		// so that we don't try to shutdown while an init is underway
 		sessionInitWaitGroup: &sync.WaitGroup{},
@@ -113,9 +115,10 @@ func (c *DbClient) SchemaMetadata() *schema.Metadata {
 	return c.schemaMetadata
 }
 
+// ConnectionMap implements Client
+// return the connections loaded from the database catalog
 func (c *DbClient) ConnectionMap() *steampipeconfig.ConnectionDataMap {
-	// TODO how to we get connections for remote DB
-	return &steampipeconfig.ConnectionDataMap{}
+	return c.connectionMap
 }
 
 // LoadSchema implements Client
@@ -213,9 +216,13 @@ func (c *DbClient) getSchemaFromDB(conn *sql.Conn) (*sql.Rows, error) {
 
 // RefreshConnectionAndSearchPaths implements Client
 func (c *DbClient) RefreshConnectionAndSearchPaths() *steampipeconfig.RefreshConnectionResult {
-	// base db client does not refresh connections, it just sets search path
+	// base db client does not refresh connections, it just reloads the connection list and sets search path
 	// (only local db client refreshed connections)
 	res := &steampipeconfig.RefreshConnectionResult{}
+	if err := c.loadConnectionMap(context.Background()); err != nil {
+		res.Error = err
+		return res
+	}
 	if err := c.SetSessionSearchPath(); err != nil {
 		res.Error = err
 	}
@@ -0,0 +1,75 @@
+package db_client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/db/db_common"
+	"github.com/turbot/steampipe/steampipeconfig"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/utils"
+)
+
+// loadConnectionMap builds the connection map from the connection schemas in the database catalog
+// (for a remote database the local connection state file does not describe the connections of the service)
+// each connection schema has a comment identifying the plugin it was imported from
+func (c *DbClient) loadConnectionMap(ctx context.Context) error {
+	utils.LogTime("db_client.loadConnectionMap start")
+	defer utils.LogTime("db_client.loadConnectionMap end")
+
+	query := fmt.Sprintf(`
+		SELECT
+			nspname,
+			obj_description(oid, 'pg_namespace')
+		FROM
+			pg_catalog.pg_namespace
+		WHERE
+			obj_description(oid, 'pg_namespace') LIKE '%s%%'
+		ORDER BY
+			nspname;
+`, db_common.ConnectionSchemaCommentPrefix)
+
+	rows, err := c.dbClient.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	schemaComments := make(map[string]string)
+	for rows.Next() {
+		var schemaName, comment string
+		if err := rows.Scan(&schemaName, &comment); err != nil {
+			return err
+		}
+		schemaComments[schemaName] = comment
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	connectionMap := connectionMapFromSchemaComments(schemaComments)
+	c.connectionMap = &connectionMap
+	return nil
+}
+
+// connectionMapFromSchemaComments builds a connection map from a map of connection schema name to schema comment
+// schemas whose comment does not identify a plugin are ignored
+func connectionMapFromSchemaComments(schemaComments map[string]string) steampipeconfig.ConnectionDataMap {
+	connectionMap := steampipeconfig.ConnectionDataMap{}
+	for schemaName, comment := range schemaComments {
+		if !strings.HasPrefix(comment, db_common.ConnectionSchemaCommentPrefix) {
+			continue
+		}
+		pluginName := strings.TrimPrefix(comment, db_common.ConnectionSchemaCommentPrefix)
+		if pluginName == "" {
+			continue
+		}
+		connection := &modconfig.Connection{
+			Name:   schemaName,
+			Plugin: pluginName,
+		}
+		connectionMap[schemaName] = steampipeconfig.NewConnectionData(pluginName, "", connection)
+	}
+	return connectionMap
+}
@@ -0,0 +1,60 @@
+package db_client
+
+import (
+	"testing"
+)
+
+type connectionMapTest struct {
+	schemaComments map[string]string
+	// expected map of connection name to plugin
+	expected map[string]string
+}
+
+var testCasesConnectionMapFromSchemaComments = map[string]connectionMapTest{
+	"no schemas": {
+		schemaComments: map[string]string{},
+		expected:       map[string]string{},
+	},
+	"connection schemas": {
+		schemaComments: map[string]string{
+			"aws":     "steampipe plugin: hub.steampipe.io/plugins/turbot/aws@latest",
+			"aws_dev": "steampipe plugin: hub.steampipe.io/plugins/turbot/aws@latest",
+			"github":  "steampipe plugin: hub.steampipe.io/plugins/turbot/github@latest",
+		},
+		expected: map[string]string{
+			"aws":     "hub.steampipe.io/plugins/turbot/aws@latest",
+			"aws_dev": "hub.steampipe.io/plugins/turbot/aws@latest",
+			"github":  "hub.steampipe.io/plugins/turbot/github@latest",
+		},
+	},
+	"ignore other schemas": {
+		schemaComments: map[string]string{
+			"aws":    "steampipe plugin: hub.steampipe.io/plugins/turbot/aws@latest",
+			"public": "standard public schema",
+			"empty":  "steampipe plugin: ",
+		},
+		expected: map[string]string{
+			"aws": "hub.steampipe.io/plugins/turbot/aws@latest",
+		},
+	},
+}
+
+func TestConnectionMapFromSchemaComments(t *testing.T) {
+	for name, test := range testCasesConnectionMapFromSchemaComments {
+		connectionMap := connectionMapFromSchemaComments(test.schemaComments)
+		if len(connectionMap) != len(test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %d connections, got %d", name, len(test.expected), len(connectionMap))
+			continue
+		}
+		for connectionName, plugin := range test.expected {
+			connectionData, ok := connectionMap[connectionName]
+			if !ok {
+				t.Errorf("Test: '%s'' FAILED : expected connection %s", name, connectionName)
+				continue
+			}
+			if connectionData.Plugin != plugin || connectionData.Connection.Name != connectionName || connectionData.Connection.Plugin != plugin {
+				t.Errorf("Test: '%s'' FAILED : expected connection %s to use plugin %s, got %v", name, connectionName, plugin, connectionData)
+			}
+		}
+	}
+}
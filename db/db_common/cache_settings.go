@@ -0,0 +1,99 @@
+package db_common
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/steampipeconfig"
+)
+
+// SetSessionCacheSettings applies the cache settings of a query to a database session, using the FDW cache command table
+// - if cacheEnabled is set, caching is turned on or off for the session
+// - the FDW cache TTL is a connection option and cannot be changed for a session, so cacheTTL is applied as
+// the maximum age of a cached result: if it is shorter than the connection cache TTL, caching is turned off for the session
+// a cacheTTL longer than the connection cache TTL cannot be applied - cached results still expire after the connection cache TTL
+//
+// the FDW cache state lasts for the lifetime of the database backend, so if a cache command is sent, the returned function
+// discards the underlying connection rather than returning it to the pool - this must be called before the session is released
+func SetSessionCacheSettings(ctx context.Context, client Client, session *DatabaseSession, cacheEnabled *bool, cacheTTL *int) (func(), error) {
+	connectionCacheTTL := defaultConnectionCacheTTL()
+	if cacheTTL != nil && *cacheTTL > connectionCacheTTL {
+		warnCacheTTLNotApplied(*cacheTTL, connectionCacheTTL)
+	}
+	command := sessionCacheCommand(cacheEnabled, cacheTTL, connectionCacheTTL)
+	if command == "" {
+		return func() {}, nil
+	}
+
+	reset := func() {
+		discardSessionConnection(session)
+	}
+
+	query := fmt.Sprintf("insert into %s.%s (%s) values ('%s')",
+		constants.CommandSchema,
+		constants.CacheCommandTable,
+		constants.CacheCommandOperationColumn,
+		command)
+	if _, err := client.ExecuteSyncInSession(ctx, session, query, true); err != nil {
+		reset()
+		return nil, fmt.Errorf("failed to apply cache settings: %s", err.Error())
+	}
+	return reset, nil
+}
+
+// sessionCacheCommand returns the FDW cache command required to apply the cache settings,
+// or an empty string if the connection cache options already satisfy them
+func sessionCacheCommand(cacheEnabled *bool, cacheTTL *int, connectionCacheTTL int) string {
+	if cacheEnabled != nil && !*cacheEnabled {
+		return constants.CommandCacheOff
+	}
+	// cached results may be as old as the connection cache TTL - if that is too old, do not use the cache
+	if cacheTTL != nil && *cacheTTL < connectionCacheTTL {
+		return constants.CommandCacheOff
+	}
+	if cacheEnabled != nil {
+		return constants.CommandCacheOn
+	}
+	return ""
+}
+
+// defaultConnectionCacheTTL returns the cache TTL of the default connection options,
+// or the FDW default if the connection options do not set it
+// (for a remote database this is an approximation, as the service connection options are not known)
+func defaultConnectionCacheTTL() int {
+	if config := steampipeconfig.GlobalConfig; config != nil && config.DefaultConnectionOptions != nil && config.DefaultConnectionOptions.CacheTTL != nil {
+		return *config.DefaultConnectionOptions.CacheTTL
+	}
+	return constants.FdwDefaultCacheTTL
+}
+
+// the cache TTLs which have already been warned about
+var warnedCacheTTLs sync.Map
+
+// warnCacheTTLNotApplied logs (once for each TTL) that a cache TTL is longer than the connection cache TTL
+func warnCacheTTLNotApplied(cacheTTL, connectionCacheTTL int) {
+	if _, warned := warnedCacheTTLs.LoadOrStore(cacheTTL, true); warned {
+		return
+	}
+	log.Printf("[WARN] cache TTL of %ds is longer than the connection cache TTL of %ds - cached results will expire after %ds", cacheTTL, connectionCacheTTL, connectionCacheTTL)
+}
+
+// discardSessionConnection closes the database connection of the session, removing it from the connection pool,
+// so that no other session uses the backend
+func discardSessionConnection(session *DatabaseSession) {
+	if session.Connection == nil {
+		return
+	}
+	// returning ErrBadConn causes database/sql to close the connection rather than returning it to the pool
+	err := session.Connection.Raw(func(interface{}) error { return driver.ErrBadConn })
+	if err != nil && err != driver.ErrBadConn {
+		log.Printf("[WARN] failed to discard database connection for session %d: %s", session.BackendPid, err.Error())
+	}
+	session.Connection = nil
+	// the backend pid may be reused by a new backend, which must be initialised
+	session.Initialized = false
+}
@@ -0,0 +1,106 @@
+package db_common
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/steampipeconfig"
+	"github.com/turbot/steampipe/steampipeconfig/options"
+	"github.com/turbot/steampipe/utils"
+)
+
+type sessionCacheCommandTest struct {
+	cacheEnabled       *bool
+	cacheTTL           *int
+	connectionCacheTTL int
+	expected           string
+}
+
+var testCasesSessionCacheCommand = map[string]sessionCacheCommandTest{
+	"no settings": {
+		connectionCacheTTL: 300,
+		expected:           "",
+	},
+	"cache enabled": {
+		cacheEnabled:       utils.ToBoolPointer(true),
+		connectionCacheTTL: 300,
+		expected:           constants.CommandCacheOn,
+	},
+	"cache disabled": {
+		cacheEnabled:       utils.ToBoolPointer(false),
+		connectionCacheTTL: 300,
+		expected:           constants.CommandCacheOff,
+	},
+	"ttl shorter than connection ttl": {
+		cacheTTL:           utils.ToIntegerPointer(60),
+		connectionCacheTTL: 300,
+		expected:           constants.CommandCacheOff,
+	},
+	"ttl longer than connection ttl": {
+		cacheTTL:           utils.ToIntegerPointer(600),
+		connectionCacheTTL: 300,
+		expected:           "",
+	},
+	"cache enabled with ttl shorter than connection ttl": {
+		cacheEnabled:       utils.ToBoolPointer(true),
+		cacheTTL:           utils.ToIntegerPointer(60),
+		connectionCacheTTL: 300,
+		expected:           constants.CommandCacheOff,
+	},
+	"cache enabled with ttl longer than connection ttl": {
+		cacheEnabled:       utils.ToBoolPointer(true),
+		cacheTTL:           utils.ToIntegerPointer(600),
+		connectionCacheTTL: 300,
+		expected:           constants.CommandCacheOn,
+	},
+	"cache disabled with ttl longer than connection ttl": {
+		cacheEnabled:       utils.ToBoolPointer(false),
+		cacheTTL:           utils.ToIntegerPointer(600),
+		connectionCacheTTL: 300,
+		expected:           constants.CommandCacheOff,
+	},
+}
+
+func TestSessionCacheCommand(t *testing.T) {
+	for name, test := range testCasesSessionCacheCommand {
+		command := sessionCacheCommand(test.cacheEnabled, test.cacheTTL, test.connectionCacheTTL)
+		if command != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected command '%s', got '%s'", name, test.expected, command)
+		}
+	}
+}
+
+type defaultConnectionCacheTTLTest struct {
+	config   *steampipeconfig.SteampipeConfig
+	expected int
+}
+
+var testCasesDefaultConnectionCacheTTL = map[string]defaultConnectionCacheTTLTest{
+	"no config": {
+		expected: constants.FdwDefaultCacheTTL,
+	},
+	"no connection options": {
+		config:   &steampipeconfig.SteampipeConfig{},
+		expected: constants.FdwDefaultCacheTTL,
+	},
+	"connection options without cache ttl": {
+		config:   &steampipeconfig.SteampipeConfig{DefaultConnectionOptions: &options.Connection{}},
+		expected: constants.FdwDefaultCacheTTL,
+	},
+	"connection cache ttl": {
+		config:   &steampipeconfig.SteampipeConfig{DefaultConnectionOptions: &options.Connection{CacheTTL: utils.ToIntegerPointer(60)}},
+		expected: 60,
+	},
+}
+
+func TestDefaultConnectionCacheTTL(t *testing.T) {
+	prevConfig := steampipeconfig.GlobalConfig
+	defer func() { steampipeconfig.GlobalConfig = prevConfig }()
+
+	for name, test := range testCasesDefaultConnectionCacheTTL {
+		steampipeconfig.GlobalConfig = test.config
+		if ttl := defaultConnectionCacheTTL(); ttl != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %d, got %d", name, test.expected, ttl)
+		}
+	}
+}
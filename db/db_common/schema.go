@@ -10,6 +10,10 @@ import (
 	"github.com/turbot/steampipe/utils"
 )
 
+// ConnectionSchemaCommentPrefix is the prefix of the comment set on each connection schema
+// - the remainder of the comment is the fully qualified name of the plugin for the connection
+const ConnectionSchemaCommentPrefix = "steampipe plugin: "
+
 type schemaRecord struct {
 	TableSchema       string
 	TableName         string
@@ -466,11 +466,27 @@ func installDatabaseWithPermissions(databaseName string, rawClient *sql.DB) erro
 			return err
 		}
 	}
+	// this is a new database, so no service users exist - remove any left over from a previous installation
+	if err := saveServiceUsers(nil); err != nil {
+		return err
+	}
 	return writePgHbaContent(databaseName, constants.DatabaseUser)
 }
 
 func writePgHbaContent(databaseName string, username string) error {
-	content := fmt.Sprintf(constants.PgHbaTemplate, databaseName, username)
+	template := constants.PgHbaTemplate
+	if sslClientAuth() {
+		template = constants.PgHbaClientCertTemplate
+	}
+	content := fmt.Sprintf(template, databaseName, username)
+
+	// add the rules for the service users
+	users, err := loadServiceUsers()
+	if err != nil {
+		return err
+	}
+	content += serviceUsersPgHbaContent(databaseName, users)
+
 	return ioutil.WriteFile(getPgHbaConfLocation(), []byte(content), 0600)
 }
 
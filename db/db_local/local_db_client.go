@@ -6,6 +6,7 @@ import (
 	"log"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/turbot/steampipe/constants"
@@ -151,8 +152,14 @@ func (c *LocalDbClient) ContructSearchPath(requiredSearchPath []string, searchPa
 
 // local only functions
 
-func (c *LocalDbClient) RefreshConnectionAndSearchPaths() *steampipeconfig.RefreshConnectionResult {
-	res := c.refreshConnections()
+func (c *LocalDbClient) RefreshConnectionAndSearchPaths() (res *steampipeconfig.RefreshConnectionResult) {
+	// record the duration and outcome of the refresh for the service metrics
+	startTime := time.Now()
+	defer func() {
+		recordRefreshStats(time.Since(startTime), res.Error)
+	}()
+
+	res = c.refreshConnections()
 	if res.Error != nil {
 		return res
 	}
@@ -28,6 +28,28 @@ func (c *LocalDbClient) refreshConnections() *steampipeconfig.RefreshConnectionR
 		return res
 	}
 
+	return c.applyConnectionUpdates(connectionUpdates, res)
+}
+
+// RefreshDynamicSchemas re-fetches the schema of the given connections from their plugins
+// and reimports the schema of any connection with a dynamic schema which has changed
+func (c *LocalDbClient) RefreshDynamicSchemas(connectionNames []string) *steampipeconfig.RefreshConnectionResult {
+	utils.LogTime("db.RefreshDynamicSchemas start")
+	defer utils.LogTime("db.RefreshDynamicSchemas end")
+
+	schemaNames := c.client.SchemaMetadata().GetSchemas()
+	connectionUpdates, res := steampipeconfig.NewDynamicSchemaUpdates(connectionNames, schemaNames)
+	if res.Error != nil {
+		return res
+	}
+
+	return c.applyConnectionUpdates(connectionUpdates, res)
+}
+
+// applyConnectionUpdates executes the queries required to apply the connection updates,
+// saves the connection state and reloads the database schemas
+// the outcome is added to res, which is returned
+func (c *LocalDbClient) applyConnectionUpdates(connectionUpdates *steampipeconfig.ConnectionUpdates, res *steampipeconfig.RefreshConnectionResult) *steampipeconfig.RefreshConnectionResult {
 	// if any plugins are missing, error for now but we could prompt for an install
 	missingCount := len(connectionUpdates.MissingPlugins)
 	if missingCount > 0 {
@@ -73,7 +95,6 @@ func (c *LocalDbClient) refreshConnections() *steampipeconfig.RefreshConnectionR
 
 	res.UpdatedConnections = true
 	return res
-
 }
 
 func (c *LocalDbClient) buildConnectionUpdateQueries(connectionUpdates *steampipeconfig.ConnectionUpdates) ([]string, *steampipeconfig.RefreshConnectionResult) {
@@ -150,7 +171,7 @@ func updateConnectionQuery(localSchema, remoteSchema string) []string {
 		// are owned by the root user.
 		fmt.Sprintf(`drop schema if exists %s cascade;`, localSchema),
 		fmt.Sprintf(`create schema %s;`, localSchema),
-		fmt.Sprintf(`comment on schema %s is 'steampipe plugin: %s';`, localSchema, remoteSchema),
+		fmt.Sprintf(`comment on schema %s is '%s%s';`, localSchema, db_common.ConnectionSchemaCommentPrefix, remoteSchema),
 
 		// Steampipe users are allowed to use the new schema
 		fmt.Sprintf(`grant usage on schema %s to steampipe_users;`, localSchema),
@@ -113,3 +113,15 @@ func getLegacyPasswordFileLocation() string {
 func getPasswordFileLocation() string {
 	return filepath.Join(constants.InternalDir(), ".passwd")
 }
+
+func getServiceUsersFileLocation() string {
+	return filepath.Join(constants.InternalDir(), "service_users.json")
+}
+
+func getRefreshStatsFileLocation() string {
+	return filepath.Join(constants.InternalDir(), "refresh_stats.json")
+}
+
+func getRefreshStatsLockFileLocation() string {
+	return filepath.Join(constants.InternalDir(), "refresh_stats.lock")
+}
@@ -0,0 +1,98 @@
+package db_local
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/turbot/go-kit/helpers"
+)
+
+// RefreshStats contains cumulative statistics about connection refreshes, for the service metrics
+// refreshes may be run by any steampipe process, so the stats are stored in a file
+type RefreshStats struct {
+	Count        int64
+	Errors       int64
+	TotalSeconds float64
+	LastSeconds  float64
+	LastRefresh  time.Time
+}
+
+// LoadRefreshStats loads the connection refresh statistics - if there have been no refreshes, empty stats are returned
+func LoadRefreshStats() (*RefreshStats, error) {
+	stats := &RefreshStats{}
+	if !helpers.FileExists(getRefreshStatsFileLocation()) {
+		return stats, nil
+	}
+	fileContent, err := ioutil.ReadFile(getRefreshStatsFileLocation())
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(fileContent, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// recordRefreshStats adds a connection refresh to the refresh statistics
+// failure to record is logged, but is not an error
+func recordRefreshStats(duration time.Duration, refreshErr error) {
+	// refreshes in other steampipe processes may be recording their stats at the same time
+	unlock, err := lockRefreshStats()
+	if err != nil {
+		log.Printf("[TRACE] failed to lock refresh stats: %s", err.Error())
+		return
+	}
+	defer unlock()
+
+	stats, err := LoadRefreshStats()
+	if err != nil {
+		log.Printf("[TRACE] failed to load refresh stats - resetting them: %s", err.Error())
+		stats = &RefreshStats{}
+	}
+	stats.Count++
+	if refreshErr != nil {
+		stats.Errors++
+	}
+	stats.LastSeconds = duration.Seconds()
+	stats.TotalSeconds += stats.LastSeconds
+	stats.LastRefresh = time.Now()
+
+	if err := saveRefreshStats(stats); err != nil {
+		log.Printf("[TRACE] failed to save refresh stats: %s", err.Error())
+	}
+}
+
+// saveRefreshStats writes the stats to a temporary file and renames it,
+// so that LoadRefreshStats (which does not take the lock) never reads a partially written file
+func saveRefreshStats(stats *RefreshStats) error {
+	content, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempFile := getRefreshStatsFileLocation() + ".tmp"
+	if err := ioutil.WriteFile(tempFile, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempFile, getRefreshStatsFileLocation())
+}
+
+// lockRefreshStats takes an exclusive lock on the refresh stats lock file, blocking until it is available
+// it returns a function which releases the lock
+func lockRefreshStats() (func(), error) {
+	lockFile, err := os.OpenFile(getRefreshStatsLockFileLocation(), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		lockFile.Close()
+		return nil, err
+	}
+	return func() {
+		// closing the file releases the lock
+		lockFile.Close()
+	}, nil
+}
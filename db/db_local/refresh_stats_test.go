@@ -0,0 +1,53 @@
+package db_local
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/constants"
+)
+
+func TestRecordRefreshStatsConcurrent(t *testing.T) {
+	installDir, err := ioutil.TempDir("", "refresh_stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(installDir)
+	prevDir := constants.SteampipeDir
+	constants.SteampipeDir = installDir
+	defer func() { constants.SteampipeDir = prevDir }()
+
+	// each goroutine opens its own lock file descriptor, so they contend for the lock in the same way as separate processes
+	refreshCount := 20
+	var wg sync.WaitGroup
+	for i := 0; i < refreshCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var refreshErr error
+			if i%2 == 0 {
+				refreshErr = errors.New("refresh failed")
+			}
+			recordRefreshStats(time.Second, refreshErr)
+		}(i)
+	}
+	wg.Wait()
+
+	stats, err := LoadRefreshStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Count != int64(refreshCount) {
+		t.Errorf("expected %d refreshes, got %d", refreshCount, stats.Count)
+	}
+	if stats.Errors != int64(refreshCount/2) {
+		t.Errorf("expected %d errors, got %d", refreshCount/2, stats.Errors)
+	}
+	if stats.TotalSeconds != float64(refreshCount) {
+		t.Errorf("expected %d total seconds, got %f", refreshCount, stats.TotalSeconds)
+	}
+}
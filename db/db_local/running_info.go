@@ -3,6 +3,7 @@ package db_local
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 
@@ -21,6 +22,11 @@ type RunningDBInstanceInfo struct {
 	Password   string
 	User       string
 	Database   string
+	// the source and location of the server certificate - empty if ssl is off
+	SslCertSource string `json:",omitempty"`
+	SslCertFile   string `json:",omitempty"`
+	// the port the plugin manager serves service metrics on - zero if metrics are disabled
+	MetricsPort int `json:",omitempty"`
 }
 
 func (r *RunningDBInstanceInfo) Save() error {
@@ -70,3 +76,18 @@ func loadRunningInstanceInfo() (*RunningDBInstanceInfo, error) {
 func removeRunningInstanceInfo() error {
 	return os.Remove(constants.RunningInfoFilePath())
 }
+
+// SslDescription returns a description of the ssl status of the service, including the certificate source and expiry
+func (r *RunningDBInstanceInfo) SslDescription() string {
+	if r.SslCertSource == "" {
+		return "off"
+	}
+	description := fmt.Sprintf("on (%s certificate", r.SslCertSource)
+	if r.SslCertSource == SslCertSourceCustom {
+		description = fmt.Sprintf("%s %s", description, r.SslCertFile)
+	}
+	if expiry, err := CertificateExpiry(r.SslCertFile); err == nil {
+		description = fmt.Sprintf("%s, expires %s", description, expiry.Format("2006-01-02"))
+	}
+	return description + ")"
+}
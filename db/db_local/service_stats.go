@@ -0,0 +1,41 @@
+package db_local
+
+import (
+	"github.com/turbot/steampipe/constants"
+)
+
+// ServiceStats contains statistics about the running database service
+type ServiceStats struct {
+	// the number of client sessions connected to the steampipe database (excluding the session used to get the stats)
+	Sessions int64
+	// the number of sessions currently executing a query
+	ActiveSessions int64
+	// the number of blocks found in, and read into, the postgres buffer cache
+	// (this is the postgres page cache - not the steampipe query cache, which is held by each plugin process)
+	BlocksHit  int64
+	BlocksRead int64
+}
+
+// GetServiceStats connects to the service as root and retrieves the service statistics
+func GetServiceStats() (*ServiceStats, error) {
+	rootClient, err := createLocalDbClient(&CreateDbOptions{Username: constants.DatabaseSuperUser})
+	if err != nil {
+		return nil, err
+	}
+	defer rootClient.Close()
+
+	stats := &ServiceStats{}
+	row := rootClient.QueryRow(`select
+  count(*),
+  count(*) filter (where state = 'active')
+from pg_stat_activity
+where datname = current_database() and backend_type = 'client backend' and pid <> pg_backend_pid()`)
+	if err := row.Scan(&stats.Sessions, &stats.ActiveSessions); err != nil {
+		return nil, err
+	}
+	row = rootClient.QueryRow("select blks_hit, blks_read from pg_stat_database where datname = current_database()")
+	if err := row.Scan(&stats.BlocksHit, &stats.BlocksRead); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
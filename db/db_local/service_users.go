@@ -0,0 +1,216 @@
+package db_local
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/utils"
+)
+
+var serviceUserNameRegex = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// ServiceUser is a named database user added with 'steampipe service user add'
+// it has the same permissions as the steampipe user
+// the password is not stored - only postgres knows it
+// json tags needed as this is stored in the service users file
+type ServiceUser struct {
+	Name string `json:"name"`
+	// the address ranges the user may connect from - if empty, the user may connect from any address
+	Cidrs []string `json:"cidrs,omitempty"`
+}
+
+// AddServiceUser creates a postgres role for a service user, with the same grants as the steampipe user,
+// and regenerates pg_hba.conf to allow the user to connect from the given address ranges
+// if no password is given, one is generated
+// it returns the password of the user
+func AddServiceUser(name, password string, cidrs []string) (string, error) {
+	utils.LogTime("db_local.AddServiceUser start")
+	defer utils.LogTime("db_local.AddServiceUser end")
+
+	if err := validateServiceUser(name, cidrs); err != nil {
+		return "", err
+	}
+	users, err := loadServiceUsers()
+	if err != nil {
+		return "", err
+	}
+	for _, user := range users {
+		if user.Name == name {
+			return "", fmt.Errorf("service user '%s' already exists", name)
+		}
+	}
+	if password == "" {
+		password = generatePassword()
+	}
+
+	rootClient, err := createLocalDbClient(&CreateDbOptions{Username: constants.DatabaseSuperUser})
+	if err != nil {
+		return "", err
+	}
+	defer rootClient.Close()
+
+	statements := []string{
+		// the user gets the privileges of steampipe_users - the same as the steampipe user
+		fmt.Sprintf(`create user %s with password '%s'`, name, strings.ReplaceAll(password, "'", "''")),
+		fmt.Sprintf("grant %s to %s", constants.DatabaseUsersRole, name),
+	}
+	for _, statement := range statements {
+		// not logging here, since the password may get logged
+		if _, err := rootClient.Exec(statement); err != nil {
+			return "", err
+		}
+	}
+
+	users = append(users, &ServiceUser{Name: name, Cidrs: cidrs})
+	if err := saveServiceUsers(users); err != nil {
+		return "", err
+	}
+	return password, refreshPgHbaConf(rootClient)
+}
+
+// RemoveServiceUser drops the postgres role for a service user and removes it from pg_hba.conf
+// any objects owned by the user are reassigned to root
+func RemoveServiceUser(name string) error {
+	utils.LogTime("db_local.RemoveServiceUser start")
+	defer utils.LogTime("db_local.RemoveServiceUser end")
+
+	users, err := loadServiceUsers()
+	if err != nil {
+		return err
+	}
+	var remainingUsers []*ServiceUser
+	for _, user := range users {
+		if user.Name != name {
+			remainingUsers = append(remainingUsers, user)
+		}
+	}
+	if len(remainingUsers) == len(users) {
+		return fmt.Errorf("service user '%s' does not exist", name)
+	}
+
+	rootClient, err := createLocalDbClient(&CreateDbOptions{Username: constants.DatabaseSuperUser})
+	if err != nil {
+		return err
+	}
+	defer rootClient.Close()
+
+	statements := []string{
+		// the user may have created tables in the public schema
+		fmt.Sprintf("reassign owned by %s to %s", name, constants.DatabaseSuperUser),
+		// revoke any remaining privileges, so that the role can be dropped
+		fmt.Sprintf("drop owned by %s", name),
+		fmt.Sprintf("drop user %s", name),
+	}
+	for _, statement := range statements {
+		log.Println("[TRACE] RemoveServiceUser: ", statement)
+		if _, err := rootClient.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	if err := saveServiceUsers(remainingUsers); err != nil {
+		return err
+	}
+	return refreshPgHbaConf(rootClient)
+}
+
+// ListServiceUsers returns the service users, ordered by name
+func ListServiceUsers() ([]*ServiceUser, error) {
+	users, err := loadServiceUsers()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Name < users[j].Name
+	})
+	return users, nil
+}
+
+// serviceUsersPgHbaContent returns the pg_hba.conf rules allowing the service users to connect
+func serviceUsersPgHbaContent(databaseName string, users []*ServiceUser) string {
+	if len(users) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(`
+# Service users added with 'steampipe service user add'. They have the same
+# permissions as the steampipe user, but always require a password and may be
+# restricted to a set of address ranges.
+#
+`)
+	template := constants.PgHbaServiceUserTemplate
+	if sslClientAuth() {
+		template = constants.PgHbaServiceUserClientCertTemplate
+	}
+	for _, user := range users {
+		cidrs := user.Cidrs
+		if len(cidrs) == 0 {
+			cidrs = []string{"all"}
+		}
+		for _, cidr := range cidrs {
+			b.WriteString(fmt.Sprintf(template, databaseName, user.Name, cidr))
+		}
+	}
+	return b.String()
+}
+
+// refreshPgHbaConf regenerates pg_hba.conf and signals the service to reload it
+func refreshPgHbaConf(rootClient *sql.DB) error {
+	var databaseName string
+	if err := rootClient.QueryRow("select current_database()").Scan(&databaseName); err != nil {
+		return err
+	}
+	if err := writePgHbaContent(databaseName, constants.DatabaseUser); err != nil {
+		return err
+	}
+	_, err := rootClient.Exec("select pg_reload_conf()")
+	return err
+}
+
+func validateServiceUser(name string, cidrs []string) error {
+	if !serviceUserNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid user name '%s' - user names may only contain lowercase letters, digits and underscores", name)
+	}
+	reservedNames := []string{constants.DatabaseSuperUser, constants.DatabaseUser, constants.DatabaseUsersRole, "postgres", "all"}
+	if helpers.StringSliceContains(reservedNames, name) {
+		return fmt.Errorf("'%s' is a reserved user name", name)
+	}
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid address range '%s' - expected a CIDR such as 10.0.0.0/8", cidr)
+		}
+	}
+	return nil
+}
+
+func loadServiceUsers() ([]*ServiceUser, error) {
+	if !helpers.FileExists(getServiceUsersFileLocation()) {
+		return nil, nil
+	}
+	fileContent, err := ioutil.ReadFile(getServiceUsersFileLocation())
+	if err != nil {
+		return nil, err
+	}
+	var users []*ServiceUser
+	if err := json.Unmarshal(fileContent, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func saveServiceUsers(users []*ServiceUser) error {
+	content, err := json.MarshalIndent(users, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(getServiceUsersFileLocation(), content, 0600)
+}
@@ -0,0 +1,135 @@
+package db_local
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+)
+
+type validateServiceUserTest struct {
+	name        string
+	cidrs       []string
+	expectError bool
+}
+
+var testCasesValidateServiceUser = map[string]validateServiceUserTest{
+	"valid name": {
+		name: "alice",
+	},
+	"valid name with digits and underscores": {
+		name: "_alice_2",
+	},
+	"valid cidrs": {
+		name:  "alice",
+		cidrs: []string{"10.0.0.0/8", "192.168.1.1/32", "::1/128"},
+	},
+	"uppercase name": {
+		name:        "Alice",
+		expectError: true,
+	},
+	"name starting with digit": {
+		name:        "2alice",
+		expectError: true,
+	},
+	"name with quote": {
+		name:        "alice'; drop table foo; --",
+		expectError: true,
+	},
+	"empty name": {
+		name:        "",
+		expectError: true,
+	},
+	"reserved name root": {
+		name:        constants.DatabaseSuperUser,
+		expectError: true,
+	},
+	"reserved name steampipe": {
+		name:        constants.DatabaseUser,
+		expectError: true,
+	},
+	"reserved name postgres": {
+		name:        "postgres",
+		expectError: true,
+	},
+	"reserved name all": {
+		name:        "all",
+		expectError: true,
+	},
+	"address without mask": {
+		name:        "alice",
+		cidrs:       []string{"10.0.0.1"},
+		expectError: true,
+	},
+	"invalid cidr": {
+		name:        "alice",
+		cidrs:       []string{"10.0.0.0/8", "foo"},
+		expectError: true,
+	},
+}
+
+func TestValidateServiceUser(t *testing.T) {
+	for name, test := range testCasesValidateServiceUser {
+		err := validateServiceUser(test.name, test.cidrs)
+		if test.expectError && err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected error but did not get one", name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+		}
+	}
+}
+
+type serviceUsersPgHbaContentTest struct {
+	users         []*ServiceUser
+	sslClientAuth bool
+	expected      string
+}
+
+const serviceUsersPgHbaHeader = `
+# Service users added with 'steampipe service user add'. They have the same
+# permissions as the steampipe user, but always require a password and may be
+# restricted to a set of address ranges.
+#
+`
+
+var testCasesServiceUsersPgHbaContent = map[string]serviceUsersPgHbaContentTest{
+	"no users": {
+		expected: "",
+	},
+	"user without cidrs": {
+		users: []*ServiceUser{{Name: "alice"}},
+		expected: serviceUsersPgHbaHeader +
+			"hostssl steampipe alice all scram-sha-256\n" +
+			"host    steampipe alice all scram-sha-256\n",
+	},
+	"users with cidrs": {
+		users: []*ServiceUser{
+			{Name: "alice", Cidrs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+			{Name: "bob", Cidrs: []string{"127.0.0.1/32"}},
+		},
+		expected: serviceUsersPgHbaHeader +
+			"hostssl steampipe alice 10.0.0.0/8 scram-sha-256\n" +
+			"host    steampipe alice 10.0.0.0/8 scram-sha-256\n" +
+			"hostssl steampipe alice 192.168.0.0/16 scram-sha-256\n" +
+			"host    steampipe alice 192.168.0.0/16 scram-sha-256\n" +
+			"hostssl steampipe bob 127.0.0.1/32 scram-sha-256\n" +
+			"host    steampipe bob 127.0.0.1/32 scram-sha-256\n",
+	},
+	"ssl client auth": {
+		users:         []*ServiceUser{{Name: "alice", Cidrs: []string{"10.0.0.0/8"}}},
+		sslClientAuth: true,
+		expected: serviceUsersPgHbaHeader +
+			"hostssl steampipe alice 10.0.0.0/8 scram-sha-256 clientcert=verify-ca\n",
+	},
+}
+
+func TestServiceUsersPgHbaContent(t *testing.T) {
+	defer viper.Set(constants.ArgSslClientAuth, false)
+	for name, test := range testCasesServiceUsersPgHbaContent {
+		viper.Set(constants.ArgSslClientAuth, test.sslClientAuth)
+		if content := serviceUsersPgHbaContent("steampipe", test.users); content != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected:\n%s\ngot:\n%s", name, test.expected, content)
+		}
+	}
+}
@@ -12,14 +12,23 @@ import (
 	"log"
 	"math/big"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/spf13/viper"
 	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/utils"
 )
 
 const CertIssuer = "steampipe.io"
 
+// the source of the server certificate used by the service
+const (
+	SslCertSourceSelfSigned = "self-signed"
+	SslCertSourceCustom     = "custom"
+)
+
 var (
 	CertExpiryTolerance      = 180 * (24 * time.Hour)     // 180 days
 	RootCertValidityPeriod   = 5 * 365 * (24 * time.Hour) // 5 years
@@ -82,7 +91,11 @@ func ValidateServerCertificate() bool {
 }
 
 // if certificate or private key files do not exist, generate them
+// if a custom certificate is configured, nothing is generated
 func ensureSelfSignedCertificate() (err error) {
+	if usingCustomCertificate() {
+		return nil
+	}
 	if serverCertificateAndKeyExist() && rootCertificateAndKeyExists() {
 		return nil
 	}
@@ -129,7 +142,7 @@ func parseCertificateInLocation(location string) (*x509.Certificate, error) {
 	utils.LogTime("db_local.parseCertificateInLocation start")
 	defer utils.LogTime("db_local.parseCertificateInLocation end")
 
-	rootCertRaw, err := ioutil.ReadFile(getRootCertLocation())
+	rootCertRaw, err := ioutil.ReadFile(location)
 	if err != nil {
 		// if we can't read the certificate, then there's a problem with permissions
 		return nil, err
@@ -261,7 +274,7 @@ func sslStatus() string {
 
 // derive ssl mode from the prsesnce of the server certificate and key file
 func sslMode() string {
-	if serverCertificateAndKeyExist() {
+	if usingCustomCertificate() || serverCertificateAndKeyExist() {
 		return "require"
 	}
 	return "disable"
@@ -337,3 +350,111 @@ func loadRootPrivateKey() (*rsa.PrivateKey, error) {
 func writeCertFile(filePath string, cert string) error {
 	return ioutil.WriteFile(filePath, []byte(cert), 0600)
 }
+
+// usingCustomCertificate returns whether the ssl_cert database option is set,
+// i.e. the service uses a certificate provided by the user rather than a self-signed certificate
+func usingCustomCertificate() bool {
+	return viper.GetString(constants.ArgSslCert) != ""
+}
+
+// sslCertSource returns the source of the server certificate
+func sslCertSource() string {
+	if usingCustomCertificate() {
+		return SslCertSourceCustom
+	}
+	return SslCertSourceSelfSigned
+}
+
+// sslCertFile returns the location of the server certificate - either the custom certificate or the self-signed certificate
+func sslCertFile() string {
+	if usingCustomCertificate() {
+		return tildefyOptionPath(constants.ArgSslCert)
+	}
+	return getServerCertLocation()
+}
+
+// sslKeyFile returns the location of the server private key - either the custom key or the self-signed key
+func sslKeyFile() string {
+	if usingCustomCertificate() {
+		return tildefyOptionPath(constants.ArgSslKey)
+	}
+	return getServerCertKeyLocation()
+}
+
+// sslCaFile returns the location of the CA certificate used to verify client certificates, if set
+func sslCaFile() string {
+	return tildefyOptionPath(constants.ArgSslCa)
+}
+
+// sslClientAuth returns whether network clients must present a certificate signed by the ssl_ca
+func sslClientAuth() bool {
+	return viper.GetBool(constants.ArgSslClientAuth)
+}
+
+func tildefyOptionPath(arg string) string {
+	location := viper.GetString(arg)
+	if location == "" {
+		return ""
+	}
+	if tildefied, err := helpers.Tildefy(location); err == nil {
+		location = tildefied
+	}
+	return location
+}
+
+// validateCustomCertificate checks the ssl database options are consistent and the files they refer to
+// contain valid certificates
+func validateCustomCertificate() error {
+	if usingCustomCertificate() {
+		if viper.GetString(constants.ArgSslKey) == "" {
+			return fmt.Errorf("ssl_key must be set if ssl_cert is set")
+		}
+		if _, err := parseCertificateInLocation(sslCertFile()); err != nil {
+			return fmt.Errorf("failed to load ssl_cert %s: %s", sslCertFile(), err.Error())
+		}
+		if !helpers.FileExists(sslKeyFile()) {
+			return fmt.Errorf("ssl_key %s does not exist", sslKeyFile())
+		}
+	} else if viper.GetString(constants.ArgSslKey) != "" {
+		return fmt.Errorf("ssl_cert must be set if ssl_key is set")
+	}
+
+	if sslCaFile() != "" {
+		if _, err := parseCertificateInLocation(sslCaFile()); err != nil {
+			return fmt.Errorf("failed to load ssl_ca %s: %s", sslCaFile(), err.Error())
+		}
+	}
+	if sslClientAuth() && sslCaFile() == "" {
+		return fmt.Errorf("ssl_ca must be set if ssl_client_auth is enabled")
+	}
+	return nil
+}
+
+// sslConfContent returns the ssl settings to add to the postgres config
+func sslConfContent() string {
+	var b strings.Builder
+	b.WriteString("\n# SSL settings - generated from the database options\n")
+	b.WriteString(fmt.Sprintf("ssl=%s\n", sslStatus()))
+	if sslStatus() == "on" {
+		b.WriteString(fmt.Sprintf("ssl_cert_file=%s\n", postgresConfString(sslCertFile())))
+		b.WriteString(fmt.Sprintf("ssl_key_file=%s\n", postgresConfString(sslKeyFile())))
+		if caFile := sslCaFile(); caFile != "" {
+			b.WriteString(fmt.Sprintf("ssl_ca_file=%s\n", postgresConfString(caFile)))
+		}
+	}
+	return b.String()
+}
+
+// postgresConfString quotes a string value for a postgres config file
+func postgresConfString(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+}
+
+// CertificateExpiry returns the expiry time of the certificate at the given location
+func CertificateExpiry(location string) (time.Time, error) {
+	certificate, err := parseCertificateInLocation(location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return certificate.NotAfter, nil
+}
@@ -0,0 +1,196 @@
+package db_local
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+)
+
+// sslOptions is the value of the ssl database options for a test
+// cert, key and ca are file names relative to the test directory
+type sslOptions struct {
+	cert       string
+	key        string
+	ca         string
+	clientAuth bool
+}
+
+func (o sslOptions) set(dir string) {
+	viper.Set(constants.ArgSslCert, testFilePath(dir, o.cert))
+	viper.Set(constants.ArgSslKey, testFilePath(dir, o.key))
+	viper.Set(constants.ArgSslCa, testFilePath(dir, o.ca))
+	viper.Set(constants.ArgSslClientAuth, o.clientAuth)
+}
+
+func testFilePath(dir, name string) string {
+	if name == "" {
+		return ""
+	}
+	return filepath.Join(dir, name)
+}
+
+// writeTestCertificateFiles writes a self-signed certificate (cert.pem), its key (key.pem)
+// and a file which is not a certificate (invalid.pem) to the given directory
+func writeTestCertificateFiles(dir string) error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "steampipe test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		"cert.pem":    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}),
+		"key.pem":     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		"invalid.pem": []byte("not a certificate"),
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), content, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type validateCustomCertificateTest struct {
+	options     sslOptions
+	expectError bool
+}
+
+var testCasesValidateCustomCertificate = map[string]validateCustomCertificateTest{
+	"no options": {
+		options: sslOptions{},
+	},
+	"cert and key": {
+		options: sslOptions{cert: "cert.pem", key: "key.pem"},
+	},
+	"cert, key and ca": {
+		options: sslOptions{cert: "cert.pem", key: "key.pem", ca: "cert.pem"},
+	},
+	"client auth with ca": {
+		options: sslOptions{cert: "cert.pem", key: "key.pem", ca: "cert.pem", clientAuth: true},
+	},
+	"client auth with self-signed certificate": {
+		options: sslOptions{ca: "cert.pem", clientAuth: true},
+	},
+	"cert without key": {
+		options:     sslOptions{cert: "cert.pem"},
+		expectError: true,
+	},
+	"key without cert": {
+		options:     sslOptions{key: "key.pem"},
+		expectError: true,
+	},
+	"missing cert": {
+		options:     sslOptions{cert: "missing.pem", key: "key.pem"},
+		expectError: true,
+	},
+	"invalid cert": {
+		options:     sslOptions{cert: "invalid.pem", key: "key.pem"},
+		expectError: true,
+	},
+	"missing key": {
+		options:     sslOptions{cert: "cert.pem", key: "missing.pem"},
+		expectError: true,
+	},
+	"invalid ca": {
+		options:     sslOptions{cert: "cert.pem", key: "key.pem", ca: "invalid.pem"},
+		expectError: true,
+	},
+	"client auth without ca": {
+		options:     sslOptions{cert: "cert.pem", key: "key.pem", clientAuth: true},
+		expectError: true,
+	},
+}
+
+func TestValidateCustomCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "steampipe_ssl_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := writeTestCertificateFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer sslOptions{}.set(dir)
+
+	for name, test := range testCasesValidateCustomCertificate {
+		test.options.set(dir)
+		err := validateCustomCertificate()
+		if test.expectError && err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected error but did not get one", name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+		}
+	}
+}
+
+type sslConfContentTest struct {
+	options  sslOptions
+	expected string
+}
+
+var testCasesSslConfContent = map[string]sslConfContentTest{
+	"custom cert": {
+		options: sslOptions{cert: "cert.pem", key: "key.pem"},
+		expected: `
+# SSL settings - generated from the database options
+ssl=on
+ssl_cert_file='{dir}/cert.pem'
+ssl_key_file='{dir}/key.pem'
+`,
+	},
+	"custom cert and ca": {
+		options: sslOptions{cert: "cert.pem", key: "key.pem", ca: "ca.pem"},
+		expected: `
+# SSL settings - generated from the database options
+ssl=on
+ssl_cert_file='{dir}/cert.pem'
+ssl_key_file='{dir}/key.pem'
+ssl_ca_file='{dir}/ca.pem'
+`,
+	},
+	"quote in path": {
+		options: sslOptions{cert: "o'brien.pem", key: "key.pem"},
+		expected: `
+# SSL settings - generated from the database options
+ssl=on
+ssl_cert_file='{dir}/o''brien.pem'
+ssl_key_file='{dir}/key.pem'
+`,
+	},
+}
+
+func TestSslConfContent(t *testing.T) {
+	// the files are not read, so the directory need not exist
+	dir := "/steampipe/certs"
+	defer sslOptions{}.set(dir)
+
+	for name, test := range testCasesSslConfContent {
+		test.options.set(dir)
+		expected := strings.ReplaceAll(test.expected, "{dir}", dir)
+		if content := sslConfContent(); content != expected {
+			t.Errorf("Test: '%s'' FAILED : expected:\n%s\ngot:\n%s", name, expected, content)
+		}
+	}
+}
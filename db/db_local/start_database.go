@@ -8,6 +8,7 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 	"sync"
 	"syscall"
@@ -41,6 +42,9 @@ const (
 	ListenTypeLocal = "local"
 )
 
+// pgHbaDatabaseNameRegex matches the pg_hba.conf rule allowing the steampipe user to connect from samehost
+var pgHbaDatabaseNameRegex = regexp.MustCompile(fmt.Sprintf(`(?m)^host(?:ssl)?\s+(\S+)\s+%s\s+samehost\s+trust\s*$`, regexp.QuoteMeta(constants.DatabaseUser)))
+
 // IsValid is a validator for StartListenType known values
 func (slt StartListenType) IsValid() error {
 	switch slt {
@@ -51,7 +55,8 @@ func (slt StartListenType) IsValid() error {
 }
 
 // StartDB starts the database if not already running
-func StartDB(port int, listen StartListenType, invoker constants.Invoker) (startResult StartResult, err error) {
+// if metricsPort is non-zero, the plugin manager serves service metrics on that port
+func StartDB(port int, listen StartListenType, invoker constants.Invoker, metricsPort int) (startResult StartResult, err error) {
 	log.Printf("[TRACE] StartDB invoker %s", invoker)
 	utils.LogTime("db.StartDB start")
 	defer utils.LogTime("db.StartDB end")
@@ -86,6 +91,11 @@ func StartDB(port int, listen StartListenType, invoker constants.Invoker) (start
 		return ServiceFailedToStart, fmt.Errorf("%s does not have the necessary permissions to start the service", getDataLocation())
 	}
 
+	// a custom certificate is explicitly configured, so fail if it is not valid
+	if err := validateCustomCertificate(); err != nil {
+		return ServiceFailedToStart, err
+	}
+
 	// Generate the certificate if it fails then set the ssl to off
 	if err := ensureSelfSignedCertificate(); err != nil {
 		utils.ShowWarning("self signed certificate creation failed, connecting to the database without SSL")
@@ -94,6 +104,11 @@ func StartDB(port int, listen StartListenType, invoker constants.Invoker) (start
 	if err := isPortBindable(port); err != nil {
 		return ServiceFailedToStart, fmt.Errorf("cannot listen on port %d", constants.Bold(port))
 	}
+	if metricsPort > 0 {
+		if err := isPortBindable(metricsPort); err != nil {
+			return ServiceFailedToStart, fmt.Errorf("cannot listen on metrics port %d", metricsPort)
+		}
+	}
 
 	if err := migrateLegacyPasswordFile(); err != nil {
 		return ServiceFailedToStart, err
@@ -104,6 +119,13 @@ func StartDB(port int, listen StartListenType, invoker constants.Invoker) (start
 		return ServiceFailedToStart, err
 	}
 
+	// write pg_hba.conf before starting the service, so that it never runs with stale rules
+	// (e.g. if the ssl client auth setting has changed)
+	pgHbaDatabaseName, err := writePgHbaConfForStart()
+	if err != nil {
+		return ServiceFailedToStart, err
+	}
+
 	postgresCmd, err = startPostgresProcess(port, listen, invoker)
 	if err != nil {
 		return ServiceFailedToStart, err
@@ -111,7 +133,7 @@ func StartDB(port int, listen StartListenType, invoker constants.Invoker) (start
 
 	// create a RunningInfo with empty database name
 	// we need this to connect to the service using 'root', required retrieve the name of the installed database
-	err = createRunningInfo(postgresCmd, port, "", password, listen, invoker)
+	err = createRunningInfo(postgresCmd, port, "", password, listen, invoker, metricsPort)
 	if err != nil {
 		return ServiceFailedToStart, err
 	}
@@ -131,6 +153,15 @@ func StartDB(port int, listen StartListenType, invoker constants.Invoker) (start
 		return ServiceFailedToStart, err
 	}
 
+	// if the database name was not known when pg_hba.conf was written, only root access was allowed
+	// - now the name is known, write the full pg_hba.conf
+	if databaseName != pgHbaDatabaseName {
+		err = ensurePgHbaConf(databaseName)
+		if err != nil {
+			return ServiceFailedToStart, err
+		}
+	}
+
 	// release the process - let the OS adopt it, so that we can exit
 	err = postgresCmd.Process.Release()
 	if err != nil {
@@ -240,7 +271,7 @@ func writePGConf() error {
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(getSteampipeConfLocation(), []byte(constants.SteampipeConfContent), 0600)
+	err = ioutil.WriteFile(getSteampipeConfLocation(), []byte(constants.SteampipeConfContent+sslConfContent()), 0600)
 	if err != nil {
 		return err
 	}
@@ -262,7 +293,7 @@ func updateDatabaseNameInRunningInfo(databaseName string) error {
 	return runningInfo.Save()
 }
 
-func createRunningInfo(cmd *exec.Cmd, port int, databaseName string, password string, listen StartListenType, invoker constants.Invoker) error {
+func createRunningInfo(cmd *exec.Cmd, port int, databaseName string, password string, listen StartListenType, invoker constants.Invoker, metricsPort int) error {
 	runningInfo := new(RunningDBInstanceInfo)
 	runningInfo.Pid = cmd.Process.Pid
 	runningInfo.Port = port
@@ -272,6 +303,11 @@ func createRunningInfo(cmd *exec.Cmd, port int, databaseName string, password st
 	runningInfo.ListenType = listen
 	runningInfo.Invoker = invoker
 	runningInfo.Listen = constants.DatabaseListenAddresses
+	runningInfo.MetricsPort = metricsPort
+	if sslStatus() == "on" {
+		runningInfo.SslCertSource = sslCertSource()
+		runningInfo.SslCertFile = sslCertFile()
+	}
 
 	if listen == ListenTypeNetwork {
 		addrs, _ := localAddresses()
@@ -295,11 +331,7 @@ func createCmd(port int, listenAddresses string) *exec.Cmd {
 		// log directory
 		"-c", fmt.Sprintf("log_directory=%s", constants.LogDir()),
 
-		// If ssl is off  it doesnot matter what we pass in the ssl_cert_file and ssl_key_file
-		// SSL will only get validated if the ssl is on
-		"-c", fmt.Sprintf("ssl=%s", sslStatus()),
-		"-c", fmt.Sprintf("ssl_cert_file=%s", getServerCertLocation()),
-		"-c", fmt.Sprintf("ssl_key_file=%s", getServerCertKeyLocation()),
+		// NOTE: ssl settings are written to steampipe.conf by writePGConf
 
 		// Data Directory
 		"-D", getDataLocation())
@@ -428,6 +460,41 @@ func ensureSteampipeServer(databaseName string) error {
 	return nil
 }
 
+// writePgHbaConfForStart writes pg_hba.conf for the database named in the existing pg_hba.conf
+// if the database name cannot be determined, pg_hba.conf is written allowing only root access,
+// and an empty database name is returned
+func writePgHbaConfForStart() (string, error) {
+	content, err := ioutil.ReadFile(getPgHbaConfLocation())
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	databaseName := pgHbaDatabaseName(string(content))
+	if databaseName == "" {
+		log.Printf("[TRACE] could not determine database name from pg_hba.conf - allowing only root access until the service has started")
+		return "", ioutil.WriteFile(getPgHbaConfLocation(), []byte(constants.MinimalPgHbaContent), 0600)
+	}
+	return databaseName, writePgHbaContent(databaseName, constants.DatabaseUser)
+}
+
+// pgHbaDatabaseName returns the name of the database the steampipe user is allowed to connect to by the given pg_hba.conf content
+func pgHbaDatabaseName(content string) string {
+	match := pgHbaDatabaseNameRegex.FindStringSubmatch(content)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// ensurePgHbaConf regenerates pg_hba.conf and reloads the service config
+func ensurePgHbaConf(databaseName string) error {
+	rootClient, err := createLocalDbClient(&CreateDbOptions{DatabaseName: databaseName, Username: constants.DatabaseSuperUser})
+	if err != nil {
+		return err
+	}
+	defer rootClient.Close()
+	return refreshPgHbaConf(rootClient)
+}
+
 // create the command schema and grant insert permission
 func ensureCommandSchema(databaseName string) error {
 	commandSchemaStatements := updateConnectionQuery(constants.CommandSchema, constants.CommandSchema)
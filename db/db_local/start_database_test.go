@@ -0,0 +1,49 @@
+package db_local
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/turbot/steampipe/constants"
+)
+
+type pgHbaDatabaseNameTest struct {
+	content  string
+	expected string
+}
+
+var testCasesPgHbaDatabaseName = map[string]pgHbaDatabaseNameTest{
+	"pg_hba template": {
+		content:  fmt.Sprintf(constants.PgHbaTemplate, "steampipe", constants.DatabaseUser),
+		expected: "steampipe",
+	},
+	"pg_hba client cert template": {
+		content:  fmt.Sprintf(constants.PgHbaClientCertTemplate, "my_db", constants.DatabaseUser),
+		expected: "my_db",
+	},
+	"pg_hba template with service users": {
+		content: fmt.Sprintf(constants.PgHbaTemplate, "steampipe", constants.DatabaseUser) +
+			fmt.Sprintf(constants.PgHbaServiceUserTemplate, "steampipe", "alice", "all"),
+		expected: "steampipe",
+	},
+	"minimal pg_hba": {
+		content:  constants.MinimalPgHbaContent,
+		expected: "",
+	},
+	"other user": {
+		content:  "host    steampipe steampipe_other samehost trust\n",
+		expected: "",
+	},
+	"empty": {
+		content:  "",
+		expected: "",
+	},
+}
+
+func TestPgHbaDatabaseName(t *testing.T) {
+	for name, test := range testCasesPgHbaDatabaseName {
+		if databaseName := pgHbaDatabaseName(test.content); databaseName != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %q, got %q", name, test.expected, databaseName)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package executionlayer
 
 import (
 	"context"
+	"log"
 
 	"github.com/turbot/steampipe/db/db_common"
 	"github.com/turbot/steampipe/report/reportevents"
@@ -10,10 +11,12 @@ import (
 	"github.com/turbot/steampipe/workspace"
 )
 
-func ExecuteReportNode(ctx context.Context, reportName string, workspace *workspace.Workspace, client db_common.Client) error {
-	executionTree, err := reportexecute.NewReportExecutionTree(reportName, client, workspace)
+// ExecuteReportNode executes the report asynchronously, using the given input values (keyed by input short name)
+// the execution tree is returned so that it may subsequently be re-executed when an input value changes
+func ExecuteReportNode(ctx context.Context, reportName string, inputValues map[string]string, workspace *workspace.Workspace, client db_common.Client) (*reportexecute.ReportExecutionTree, error) {
+	executionTree, err := reportexecute.NewReportExecutionTree(reportName, client, workspace, inputValues)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	go func() {
@@ -28,5 +31,40 @@ func ExecuteReportNode(ctx context.Context, reportName string, workspace *worksp
 		workspace.PublishReportEvent(&reportevents.ExecutionComplete{Report: executionTree.Root})
 	}()
 
+	return executionTree, nil
+}
+
+// ExecuteReportInputChange sets the value of an input of a previously executed report,
+// then asynchronously re-executes only the panels which reference that input
+func ExecuteReportInputChange(ctx context.Context, executionTree *reportexecute.ReportExecutionTree, inputName, value string, workspace *workspace.Workspace) error {
+	if err := executionTree.SetInputValue(inputName, value); err != nil {
+		return err
+	}
+
+	go func() {
+		workspace.PublishReportEvent(&reportevents.ExecutionStarted{ReportNode: executionTree.Root})
+		if err := executionTree.ExecuteForInput(ctx, inputName); err != nil {
+			log.Printf("[TRACE] re-execution of %s for input '%s' failed: %s", executionTree.Root.GetName(), inputName, err.Error())
+		}
+		workspace.PublishReportEvent(&reportevents.ExecutionComplete{Report: executionTree.Root})
+	}()
+
 	return nil
 }
+
+// ExecuteReportNodeSync executes the report synchronously, returning the root of the completed execution tree
+// if execution fails, the error is also set on the root node so it is included in any output
+func ExecuteReportNodeSync(ctx context.Context, reportName string, workspace *workspace.Workspace, client db_common.Client) (reportinterfaces.ReportNodeRun, error) {
+	executionTree, err := reportexecute.NewReportExecutionTree(reportName, client, workspace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := executionTree.Execute(ctx); err != nil {
+		if executionTree.Root.GetRunStatus() != reportinterfaces.ReportRunError {
+			executionTree.Root.SetError(err)
+		}
+		return executionTree.Root, err
+	}
+	return executionTree.Root, nil
+}
@@ -0,0 +1,65 @@
+package metrics_server
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// metric is a single metric family in the Prometheus text exposition format
+type metric struct {
+	name    string
+	help    string
+	kind    string
+	samples []sample
+}
+
+// sample is a value of a metric, with optional labels
+type sample struct {
+	labels map[string]string
+	value  float64
+}
+
+func newGauge(name, help string, value float64) *metric {
+	return &metric{name: name, help: help, kind: "gauge", samples: []sample{{value: value}}}
+}
+
+func newCounter(name, help string, value float64) *metric {
+	return &metric{name: name, help: help, kind: "counter", samples: []sample{{value: value}}}
+}
+
+// write writes the metric in the Prometheus text exposition format
+func (m *metric) write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind); err != nil {
+		return err
+	}
+	for _, s := range m.samples {
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", m.name, formatLabels(s.labels), strconv.FormatFloat(s.value, 'f', -1, 64)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatLabels formats labels as {name="value",...}, ordered by label name
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	var names []string
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pairs []string
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, labelValueReplacer.Replace(labels[name])))
+	}
+	return fmt.Sprintf("{%s}", strings.Join(pairs, ","))
+}
+
+// labelValueReplacer escapes a label value as required by the Prometheus text format
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
@@ -0,0 +1,172 @@
+package metrics_server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/turbot/steampipe/db/db_local"
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
+)
+
+// PluginLister returns the running plugin processes and failed connections
+type PluginLister func() (*pb.ListResponse, error)
+
+// MetricsServer is an HTTP server exposing Prometheus metrics for the service at /metrics
+// and a health check at /healthz
+type MetricsServer struct {
+	server      *http.Server
+	listPlugins PluginLister
+}
+
+// NewMetricsServer creates a metrics server listening on the given address
+func NewMetricsServer(address string, listPlugins PluginLister) *MetricsServer {
+	m := &MetricsServer{listPlugins: listPlugins}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", m.handleMetrics)
+	mux.HandleFunc("/healthz", m.handleHealthz)
+	m.server = &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+	return m
+}
+
+// Start starts listening - requests are served in the background
+func (m *MetricsServer) Start() error {
+	listener, err := net.Listen("tcp", m.server.Addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] metrics server listening on %s", m.server.Addr)
+	go func() {
+		if err := m.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("[WARN] metrics server stopped: %s", err.Error())
+		}
+	}()
+	return nil
+}
+
+// Close stops the server
+func (m *MetricsServer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return m.server.Shutdown(ctx)
+}
+
+func (m *MetricsServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if _, err := db_local.GetServiceStats(); err != nil {
+		http.Error(w, fmt.Sprintf("database unavailable: %s", err.Error()), http.StatusServiceUnavailable)
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+func (m *MetricsServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	// write to a buffer first, so a write error does not result in a partial response
+	var buf bytes.Buffer
+	for _, metric := range m.collect() {
+		if err := metric.write(&buf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(buf.Bytes())
+}
+
+// collect gathers the current value of all metrics
+// failure to collect a group of metrics is logged and the group is omitted (except for the database up metric)
+func (m *MetricsServer) collect() []*metric {
+	var metrics []*metric
+
+	stats, err := db_local.GetServiceStats()
+	if err != nil {
+		log.Printf("[TRACE] metrics server failed to get service stats: %s", err.Error())
+	}
+	metrics = append(metrics, databaseMetrics(stats)...)
+
+	if plugins, err := m.listPlugins(); err != nil {
+		log.Printf("[TRACE] metrics server failed to list plugins: %s", err.Error())
+	} else {
+		metrics = append(metrics, pluginMetrics(plugins)...)
+	}
+
+	if refreshStats, err := db_local.LoadRefreshStats(); err != nil {
+		log.Printf("[TRACE] metrics server failed to load refresh stats: %s", err.Error())
+	} else {
+		metrics = append(metrics, refreshMetrics(refreshStats)...)
+	}
+	return metrics
+}
+
+// databaseMetrics returns the database metrics - if stats is nil, the database is down
+//
+// the block counts are for the postgres buffer cache. The steampipe query cache hit and miss counts are held by
+// each plugin process and are not exposed by the plugin SDK, so query cache metrics are not provided
+func databaseMetrics(stats *db_local.ServiceStats) []*metric {
+	if stats == nil {
+		return []*metric{newGauge("steampipe_database_up", "Whether the database service is accepting connections.", 0)}
+	}
+	return []*metric{
+		newGauge("steampipe_database_up", "Whether the database service is accepting connections.", 1),
+		newGauge("steampipe_database_sessions", "Client sessions connected to the steampipe database.", float64(stats.Sessions)),
+		newGauge("steampipe_database_active_sessions", "Client sessions currently executing a query.", float64(stats.ActiveSessions)),
+		newCounter("steampipe_database_blocks_hit_total", "Blocks found in the postgres buffer cache.", float64(stats.BlocksHit)),
+		newCounter("steampipe_database_blocks_read_total", "Blocks read into the postgres buffer cache.", float64(stats.BlocksRead)),
+	}
+}
+
+// pluginMetrics returns the plugin process count for each connection, and the connections which have failed
+func pluginMetrics(plugins *pb.ListResponse) []*metric {
+	processes := &metric{
+		name: "steampipe_plugin_processes",
+		help: "Plugin processes running for each connection.",
+		kind: "gauge",
+	}
+	for _, p := range plugins.Plugins {
+		processes.samples = append(processes.samples, sample{
+			labels: map[string]string{"connection": p.Connection, "plugin": p.Plugin},
+			value:  1,
+		})
+	}
+	failed := &metric{
+		name: "steampipe_connection_failed",
+		help: "Connections whose plugin has crashed too often to be restarted.",
+		kind: "gauge",
+	}
+	var failedConnections []string
+	for connection := range plugins.FailedConnections {
+		failedConnections = append(failedConnections, connection)
+	}
+	sort.Strings(failedConnections)
+	for _, connection := range failedConnections {
+		failed.samples = append(failed.samples, sample{
+			labels: map[string]string{"connection": connection},
+			value:  1,
+		})
+	}
+	return []*metric{processes, failed}
+}
+
+// refreshMetrics returns the connection refresh metrics
+func refreshMetrics(stats *db_local.RefreshStats) []*metric {
+	metrics := []*metric{
+		newCounter("steampipe_refresh_connections_total", "Connection refreshes.", float64(stats.Count)),
+		newCounter("steampipe_refresh_connections_errors_total", "Connection refreshes which failed.", float64(stats.Errors)),
+		newCounter("steampipe_refresh_connections_duration_seconds_total", "Total time spent refreshing connections.", stats.TotalSeconds),
+	}
+	if stats.Count > 0 {
+		metrics = append(metrics,
+			newGauge("steampipe_refresh_connections_last_duration_seconds", "Duration of the last connection refresh.", stats.LastSeconds),
+			newGauge("steampipe_refresh_connections_last_timestamp_seconds", "Unix time of the last connection refresh.", float64(stats.LastRefresh.Unix())),
+		)
+	}
+	return metrics
+}
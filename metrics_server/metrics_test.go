@@ -0,0 +1,63 @@
+package metrics_server
+
+import (
+	"bytes"
+	"testing"
+
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
+)
+
+type metricWriteTest struct {
+	metric   *metric
+	expected string
+}
+
+var testCasesMetricWrite = map[string]metricWriteTest{
+	"gauge": {
+		metric: newGauge("steampipe_database_up", "Whether the database service is accepting connections.", 1),
+		expected: `# HELP steampipe_database_up Whether the database service is accepting connections.
+# TYPE steampipe_database_up gauge
+steampipe_database_up 1
+`,
+	},
+	"labels": {
+		metric: pluginMetrics(&pb.ListResponse{
+			Plugins: []*pb.RunningPlugin{
+				{Connection: "aws", Plugin: "hub.steampipe.io/plugins/turbot/aws@latest"},
+			},
+		})[0],
+		expected: `# HELP steampipe_plugin_processes Plugin processes running for each connection.
+# TYPE steampipe_plugin_processes gauge
+steampipe_plugin_processes{connection="aws",plugin="hub.steampipe.io/plugins/turbot/aws@latest"} 1
+`,
+	},
+	"escaped label": {
+		metric: pluginMetrics(&pb.ListResponse{
+			FailedConnections: map[string]string{`a"b\c`: "crashed"},
+		})[1],
+		expected: `# HELP steampipe_connection_failed Connections whose plugin has crashed too often to be restarted.
+# TYPE steampipe_connection_failed gauge
+steampipe_connection_failed{connection="a\"b\\c"} 1
+`,
+	},
+	"fraction": {
+		metric: newGauge("steampipe_refresh_connections_last_duration_seconds", "Duration.", 0.75),
+		expected: `# HELP steampipe_refresh_connections_last_duration_seconds Duration.
+# TYPE steampipe_refresh_connections_last_duration_seconds gauge
+steampipe_refresh_connections_last_duration_seconds 0.75
+`,
+	},
+}
+
+func TestMetricWrite(t *testing.T) {
+	for name, test := range testCasesMetricWrite {
+		var buf bytes.Buffer
+		if err := test.metric.write(&buf); err != nil {
+			t.Errorf("Test: '%s'' FAILED with unexpected error: %v", name, err)
+			continue
+		}
+		if buf.String() != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected:\n%s\ngot:\n%s", name, test.expected, buf.String())
+		}
+	}
+}
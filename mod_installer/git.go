@@ -0,0 +1,75 @@
+package mod_installer
+
+import (
+	"fmt"
+	"strings"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/storage/memory"
+	goVersion "github.com/hashicorp/go-version"
+)
+
+// getTagVersionsFromGit lists the tags of the remote repo and returns a map of tag name to version
+// for all tags which parse as a semver version
+func getTagVersionsFromGit(gitUrl string) (map[string]*goVersion.Version, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{gitUrl},
+	})
+	refs, err := remote.List(&git.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*goVersion.Version)
+	for _, ref := range refs {
+		if !ref.Name().IsTag() {
+			continue
+		}
+		tag := ref.Name().Short()
+		v, err := versionFromTag(tag)
+		if err != nil {
+			// not a version tag - ignore
+			continue
+		}
+		res[tag] = v
+	}
+	return res, nil
+}
+
+// getInstalledTag returns the name of the tag which points at the HEAD of the installed mod repo
+func getInstalledTag(installPath string) (string, error) {
+	repo, err := git.PlainOpen(installPath)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	tags, err := repo.Tags()
+	if err != nil {
+		return "", err
+	}
+	defer tags.Close()
+	for {
+		ref, err := tags.Next()
+		if err != nil {
+			return "", fmt.Errorf("no tag found for commit %s", head.Hash().String())
+		}
+		commitHash := ref.Hash()
+		// for an annotated tag, the ref points at the tag object rather than the commit
+		if tagObject, err := repo.TagObject(ref.Hash()); err == nil {
+			commitHash = tagObject.Target
+		}
+		if commitHash == head.Hash() {
+			return ref.Name().Short(), nil
+		}
+	}
+}
+
+// versionFromTag parses a version tag, e.g. v1.2
+func versionFromTag(tag string) (*goVersion.Version, error) {
+	return goVersion.NewVersion(strings.TrimPrefix(tag, "v"))
+}
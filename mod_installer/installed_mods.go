@@ -0,0 +1,144 @@
+package mod_installer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	goVersion "github.com/hashicorp/go-version"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// InstalledMod is a struct representing a mod installed in the workspace mod directory
+type InstalledMod struct {
+	// the FQN of the mod, e.g. github.com/turbot/steampipe-mod-aws-compliance
+	Name    string
+	Version *goVersion.Version
+	Path    string
+}
+
+func (m *InstalledMod) String() string {
+	return fmt.Sprintf("%s@v%s", m.Name, m.Version.Original())
+}
+
+// ListInstalledMods returns all mods installed in the workspace mod directory, sorted by name
+func (i *ModInstaller) ListInstalledMods() ([]*InstalledMod, error) {
+	var res []*InstalledMod
+	if _, err := os.Stat(i.ModsDir); os.IsNotExist(err) {
+		return res, nil
+	}
+
+	err := filepath.Walk(i.ModsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() || path == i.ModsDir {
+			return nil
+		}
+		// mods are installed to <mods dir>/<mod name>@v<version>
+		split := strings.Split(info.Name(), "@")
+		if len(split) != 2 {
+			return nil
+		}
+		v, err := goVersion.NewVersion(strings.TrimPrefix(split[1], "v"))
+		if err != nil {
+			// invalid format - ignore
+			return nil
+		}
+		relPath, err := filepath.Rel(i.ModsDir, filepath.Join(filepath.Dir(path), split[0]))
+		if err != nil {
+			return err
+		}
+		res = append(res, &InstalledMod{
+			Name:    filepath.ToSlash(relPath),
+			Version: v,
+			Path:    path,
+		})
+		// do not descend into the installed mod
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Name == res[j].Name {
+			return res[i].Version.LessThan(res[j].Version)
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+// getInstalledVersions returns the versions of the given mod which are installed in the mod directory
+func (i *ModInstaller) getInstalledVersions(modName string) (map[string]*goVersion.Version, error) {
+	parentFolder := filepath.Dir(filepath.Join(i.ModsDir, modName))
+	shortName := filepath.Base(modName)
+	entries, err := ioutil.ReadDir(parentFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*goVersion.Version)
+	for _, entry := range entries {
+		split := strings.Split(entry.Name(), "@")
+		if len(split) != 2 || split[0] != shortName {
+			continue
+		}
+		v, err := goVersion.NewVersion(strings.TrimPrefix(split[1], "v"))
+		if err != nil {
+			// invalid format - ignore
+			continue
+		}
+		res[filepath.Join(parentFolder, entry.Name())] = v
+	}
+	return res, nil
+}
+
+// deleteInstalledVersions deletes all installed versions of the given mod
+func (i *ModInstaller) deleteInstalledVersions(modName string) error {
+	installed, err := i.getInstalledVersions(modName)
+	if err != nil {
+		// nothing installed
+		return nil
+	}
+	for path := range installed {
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteOtherVersions deletes all installed versions of the dependency with the same major version,
+// other than the version of the dependency itself
+func (i *ModInstaller) deleteOtherVersions(dependency *ResolvedModRef) error {
+	installed, err := i.getInstalledVersions(dependency.Name)
+	if err != nil {
+		return err
+	}
+	for path, v := range installed {
+		if v.Segments()[0] == dependency.Version.Segments()[0] && filepath.Base(path) != filepath.Base(dependency.FullName()) {
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// versionSatisfiesConstraint returns whether the version has the same major version as the version constraint
+// of the mod version, and is greater than or equal to it
+// if the mod version does not specify a version, any version is acceptable
+func versionSatisfiesConstraint(v *goVersion.Version, modVersion *modconfig.ModVersion) bool {
+	if !modVersion.HasVersion() {
+		return true
+	}
+	if modVersion.VersionConstraint == nil {
+		return false
+	}
+	return v.Segments()[0] == modVersion.VersionConstraint.Segments()[0] && v.GreaterThanOrEqual(modVersion.VersionConstraint)
+}
@@ -0,0 +1,101 @@
+package mod_installer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// InitWorkspaceMod creates a default mod file in the workspace folder, if one does not already exist
+func InitWorkspaceMod(workspacePath string) (bool, error) {
+	modFilePath := filepath.Join(workspacePath, constants.WorkspaceModFileName)
+	if _, err := ioutil.ReadFile(modFilePath); err == nil {
+		return false, nil
+	}
+
+	f := hclwrite.NewEmptyFile()
+	modBlock := f.Body().AppendNewBlock(modconfig.BlockTypeMod, []string{constants.WorkspaceDefaultModName})
+	modBlock.Body().SetAttributeValue("title", cty.StringVal(filepath.Base(workspacePath)))
+
+	if err := ioutil.WriteFile(modFilePath, f.Bytes(), 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// setModFileRequirements sets the version of each mod in the versions map in the requires block of
+// the workspace mod file, adding the requires block and mod blocks as necessary
+func setModFileRequirements(workspacePath string, versions map[string]string) error {
+	// sort the names so mod blocks are added in a deterministic order
+	var names []string
+	for name := range versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return updateModFile(workspacePath, func(modBody *hclwrite.Body) {
+		requiresBlock := modBody.FirstMatchingBlock(modconfig.BlockTypeRequires, nil)
+		if requiresBlock == nil {
+			requiresBlock = modBody.AppendNewBlock(modconfig.BlockTypeRequires, nil)
+		}
+		requiresBody := requiresBlock.Body()
+		for _, name := range names {
+			modVersionBlock := requiresBody.FirstMatchingBlock(modconfig.BlockTypeMod, []string{name})
+			if modVersionBlock == nil {
+				modVersionBlock = requiresBody.AppendNewBlock(modconfig.BlockTypeMod, []string{name})
+			}
+			modVersionBlock.Body().SetAttributeValue("version", cty.StringVal(versions[name]))
+		}
+	})
+}
+
+// removeModFileRequirements removes the given mods from the requires block of the workspace mod file
+func removeModFileRequirements(workspacePath string, modNames []string) error {
+	return updateModFile(workspacePath, func(modBody *hclwrite.Body) {
+		requiresBlock := modBody.FirstMatchingBlock(modconfig.BlockTypeRequires, nil)
+		if requiresBlock == nil {
+			return
+		}
+		for _, name := range modNames {
+			if modVersionBlock := requiresBlock.Body().FirstMatchingBlock(modconfig.BlockTypeMod, []string{name}); modVersionBlock != nil {
+				requiresBlock.Body().RemoveBlock(modVersionBlock)
+			}
+		}
+	})
+}
+
+// updateModFile parses the workspace mod file, calls updateFunc with the body of the mod block,
+// then writes the modified file back
+// NOTE: comments and formatting of the rest of the file are preserved
+func updateModFile(workspacePath string, updateFunc func(modBody *hclwrite.Body)) error {
+	modFilePath := filepath.Join(workspacePath, constants.WorkspaceModFileName)
+	data, err := ioutil.ReadFile(modFilePath)
+	if err != nil {
+		return err
+	}
+	f, diags := hclwrite.ParseConfig(data, modFilePath, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return fmt.Errorf("failed to parse %s: %s", modFilePath, diags.Error())
+	}
+
+	var modBlock *hclwrite.Block
+	for _, block := range f.Body().Blocks() {
+		if block.Type() == modconfig.BlockTypeMod {
+			modBlock = block
+			break
+		}
+	}
+	if modBlock == nil {
+		return fmt.Errorf("no mod definition found in %s", modFilePath)
+	}
+
+	updateFunc(modBlock.Body())
+	return ioutil.WriteFile(modFilePath, hclwrite.Format(f.Bytes()), 0644)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/turbot/steampipe/constants"
 
 	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/turbot/steampipe/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/steampipeconfig/parse"
 	"github.com/turbot/steampipe/utils"
@@ -64,12 +65,129 @@ mod 2 require a@<branch>
 type ModInstaller struct {
 	ModsDir               string
 	InstalledDependencies []*ResolvedModRef
+
+	// the path of the workspace we are installing into
+	workspacePath string
+	// the workspace lock file - the versions in this are used in preference to resolving the latest version
+	workspaceLock *WorkspaceLock
+	// if set, reinstall dependencies even if a compatible version is already installed
+	updating bool
+	// function used to build the git url for a mod name - overridden by tests to point at local repos
+	gitUrlFunc func(modName string) string
 }
 
 func NewModInstaller(workspacePath string) *ModInstaller {
 	return &ModInstaller{
-		ModsDir: constants.WorkspaceModPath(workspacePath),
+		ModsDir:       constants.WorkspaceModPath(workspacePath),
+		workspacePath: workspacePath,
+		gitUrlFunc:    defaultGitUrl,
+	}
+}
+
+func defaultGitUrl(modName string) string {
+	return fmt.Sprintf("https://%s", modName)
+}
+
+// InstallWorkspaceDependencies installs all dependencies of the workspace mod,
+// and writes the resolved versions back to the requires block of the mod file
+func (i *ModInstaller) InstallWorkspaceDependencies() error {
+	workspaceMod, err := i.loadWorkspaceMod()
+	if err != nil {
+		return err
+	}
+	if err := i.InstallModDependencies(workspaceMod); err != nil {
+		return err
+	}
+	return i.writeResolvedVersions(workspaceMod)
+}
+
+// GetMods adds the specified mods as dependencies of the workspace mod and installs them
+// each mod ref is in the format name[@version]
+func (i *ModInstaller) GetMods(modRefs []string) error {
+	workspaceMod, err := i.loadWorkspaceMod()
+	if err != nil {
+		return err
+	}
+	if workspaceMod.Requires == nil {
+		workspaceMod.Requires = &modconfig.Requires{}
+	}
+	for _, r := range modRefs {
+		modRef, err := NewModRef(r)
+		if err != nil {
+			return err
+		}
+		modVersion, err := modRef.ModVersion()
+		if err != nil {
+			return err
+		}
+		workspaceMod.Requires.AddModVersion(modVersion)
+	}
+
+	if err := i.InstallModDependencies(workspaceMod); err != nil {
+		return err
 	}
+	return i.writeResolvedVersions(workspaceMod)
+}
+
+// UpdateMods installs the latest compatible version of the specified workspace dependencies
+// (or all dependencies if none are specified) and updates the requires block of the mod file
+func (i *ModInstaller) UpdateMods(modNames []string) error {
+	workspaceMod, err := i.loadWorkspaceMod()
+	if err != nil {
+		return err
+	}
+	if workspaceMod.Requires == nil {
+		return nil
+	}
+	if len(modNames) > 0 {
+		// only update the specified mods
+		var toUpdate []*modconfig.ModVersion
+		for _, name := range modNames {
+			modVersion := workspaceMod.Requires.GetModVersion(name)
+			if modVersion == nil {
+				return fmt.Errorf("%s is not a dependency of mod %s", name, workspaceMod.Name())
+			}
+			toUpdate = append(toUpdate, modVersion)
+		}
+		workspaceMod.Requires.Mods = toUpdate
+	}
+
+	i.updating = true
+	if err := i.InstallModDependencies(workspaceMod); err != nil {
+		return err
+	}
+	return i.writeResolvedVersions(workspaceMod)
+}
+
+// UninstallMods removes the specified mods from the requires block of the workspace mod
+// and deletes all installed versions of them
+func (i *ModInstaller) UninstallMods(modNames []string) error {
+	workspaceMod, err := i.loadWorkspaceMod()
+	if err != nil {
+		return err
+	}
+	for _, name := range modNames {
+		if workspaceMod.Requires == nil || workspaceMod.Requires.GetModVersion(name) == nil {
+			return fmt.Errorf("%s is not a dependency of mod %s", name, workspaceMod.Name())
+		}
+	}
+
+	var errors []error
+	for _, name := range modNames {
+		if err := i.deleteInstalledVersions(name); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if err := removeModFileRequirements(i.workspacePath, modNames); err != nil {
+		errors = append(errors, err)
+	}
+	for _, name := range modNames {
+		delete(i.workspaceLock.Mods, name)
+	}
+	if err := i.workspaceLock.Save(); err != nil {
+		errors = append(errors, err)
+	}
+	return utils.CombineErrorsWithPrefix(fmt.Sprintf("failed to uninstall %d mods", len(errors)), errors...)
 }
 
 // InstallModDependencies installs all dependencies of the mod
@@ -78,6 +196,19 @@ func (i *ModInstaller) InstallModDependencies(mod *modconfig.Mod) error {
 	return i.installModDependenciesRecursively(mod, dependencyMap)
 }
 
+// loadWorkspaceMod parses the workspace mod definition and loads the workspace lock file
+func (i *ModInstaller) loadWorkspaceMod() (*modconfig.Mod, error) {
+	if !parse.ModfileExists(i.workspacePath) {
+		return nil, fmt.Errorf("no mod definition found in %s - run 'steampipe mod init' to create one", i.workspacePath)
+	}
+	workspaceLock, err := LoadWorkspaceLock(i.workspacePath)
+	if err != nil {
+		return nil, err
+	}
+	i.workspaceLock = workspaceLock
+	return parse.ParseModDefinition(i.workspacePath)
+}
+
 func (i *ModInstaller) installModDependenciesRecursively(mod *modconfig.Mod, dependencyMap map[string]*ResolvedModRef) error {
 	if mod.Requires == nil {
 		return nil
@@ -108,29 +239,115 @@ func (i *ModInstaller) installModDependenciesRecursively(mod *modconfig.Mod, dep
 
 func (i *ModInstaller) GetModRefForVersion(modVersion *modconfig.ModVersion) (*ResolvedModRef, error) {
 
-	// NOTE check whether the lock file contains this dependency and if so
-	//  does the locked version satisy this version requirement
-	// return error if not
-
 	// NOTE check whether we are replacing this version
 	// if so does the locked version satisfy this version requirement
 	// return error if not
 
+	// local and branch dependencies do not need resolving
+	if modVersion.FilePath != "" || (modVersion.Branch != "" && modVersion.HasVersion()) {
+		return NewResolvedModRef(modVersion)
+	}
+
+	// if we are not updating and the lock file contains a version which satisfies the version constraint, use that
+	if !i.updating {
+		if locked := i.getLockedVersion(modVersion); locked != nil {
+			return locked, nil
+		}
+	}
+
+	// if we are not updating and a compatible version is already installed, use that
+	if !i.updating {
+		if installed := i.getInstalledCompatibleVersion(modVersion); installed != nil {
+			return installed, nil
+		}
+	}
+
 	// so we need to resolve this mod version
+	return i.getLatestCompatibleVersion(modVersion)
+}
+
+// getLatestCompatibleVersion lists the version tags of the mod repo and returns a ref for the
+// highest version which satisfies the version constraint
+// (i.e. has the same major version and is greater than or equal to the constraint)
+func (i *ModInstaller) getLatestCompatibleVersion(modVersion *modconfig.ModVersion) (*ResolvedModRef, error) {
+	tags, err := getTagVersionsFromGit(i.gitUrlFunc(modVersion.Name))
+	if err != nil {
+		return nil, err
+	}
 
-	// NOTE for now assume github
-	// get the most recent minor version fo rthis major version from github
-	return i.getLatestCompatibleVersionFromGithub(modVersion)
+	var res *ResolvedModRef
+	for tag, v := range tags {
+		if !versionSatisfiesConstraint(v, modVersion) {
+			continue
+		}
+		if res == nil || v.GreaterThan(res.Version) {
+			res = &ResolvedModRef{
+				Name:         modVersion.Name,
+				GitReference: plumbing.NewTagReferenceName(tag),
+				Version:      v,
+			}
+		}
+	}
+	if res == nil {
+		return nil, fmt.Errorf("no version of %s found which satisfies version %s", modVersion.Name, modVersion.VersionString)
+	}
+	return res, nil
 }
 
-func (i *ModInstaller) getLatestCompatibleVersionFromGithub(modVersion *modconfig.ModVersion) (*ResolvedModRef, error) {
-	// NOTE for now assume the mod is specified with a full version
-	return NewResolvedModRef(modVersion)
+// getLockedVersion returns a ref for the version of the mod in the workspace lock file,
+// or nil if the mod is not locked or the locked version does not satisfy the version constraint
+func (i *ModInstaller) getLockedVersion(modVersion *modconfig.ModVersion) *ResolvedModRef {
+	if i.workspaceLock == nil {
+		return nil
+	}
+	locked, ok := i.workspaceLock.Mods[modVersion.Name]
+	if !ok {
+		return nil
+	}
+	v, err := versionFromTag(locked.Version)
+	if err != nil || !versionSatisfiesConstraint(v, modVersion) {
+		return nil
+	}
+	return &ResolvedModRef{
+		Name:         modVersion.Name,
+		GitReference: plumbing.NewTagReferenceName(locked.Version),
+		Version:      v,
+	}
+}
+
+// getInstalledCompatibleVersion returns a ref for the highest installed version of the mod which
+// satisfies the version constraint, or nil if there is none
+func (i *ModInstaller) getInstalledCompatibleVersion(modVersion *modconfig.ModVersion) *ResolvedModRef {
+	installed, err := i.getInstalledVersions(modVersion.Name)
+	if err != nil {
+		return nil
+	}
+	var res *ResolvedModRef
+	for installPath, v := range installed {
+		if !versionSatisfiesConstraint(v, modVersion) {
+			continue
+		}
+		if res != nil && !v.GreaterThan(res.Version) {
+			continue
+		}
+		// use the tag which was actually installed
+		tag, err := getInstalledTag(installPath)
+		if err != nil {
+			log.Printf("[TRACE] failed to determine the installed tag of %s: %s", installPath, err.Error())
+			continue
+		}
+		res = &ResolvedModRef{
+			Name:         modVersion.Name,
+			GitReference: plumbing.NewTagReferenceName(tag),
+			Version:      v,
+		}
+	}
+	return res
 }
 
 func (i *ModInstaller) installDependency(dependency *ResolvedModRef, dependencyMap map[string]*ResolvedModRef) error {
 	// have we already installed a mod which satisfies this dependency
-	if modRef, ok := dependencyMap[dependency.Name]; ok {
+	if modRef, ok := dependencyMap[dependency.Name]; ok && modRef.Version != nil && dependency.Version != nil {
 		if modRef.Version.GreaterThanOrEqual(dependency.Version) {
 			return nil
 		}
@@ -156,6 +373,7 @@ func (i *ModInstaller) installDependency(dependency *ResolvedModRef, dependencyM
 	// no load the installed mod and install _its_ dependencies
 	if !parse.ModfileExists(modPath) {
 		log.Printf("[TRACE] dependency %s does not define a mod defintion - so there are no dependencies to install", dependency.Name)
+		i.InstalledDependencies = append(i.InstalledDependencies, dependency)
 		return nil
 	}
 
@@ -172,27 +390,102 @@ func (i *ModInstaller) installDependency(dependency *ResolvedModRef, dependencyM
 }
 
 func (i *ModInstaller) installDependencyFromGit(dependency *ResolvedModRef, installPath string) error {
+	// if this version is already installed, there is nothing to do
+	if _, err := os.Stat(installPath); err == nil {
+		log.Printf("[TRACE] dependency %s is already installed", dependency.FullName())
+		return nil
+	}
+
 	// ensure mod directory exists - create if necessary
 	if err := os.MkdirAll(i.ModsDir, os.ModePerm); err != nil {
 		return err
 	}
 
-	// NOTE: we need to check existing installed mods
-
 	// get the mod from git
-
-	gitUrl := fmt.Sprintf("https://%s", dependency.Name)
 	_, err := git.PlainClone(installPath,
 		false,
 		&git.CloneOptions{
-			URL: gitUrl,
+			URL: i.gitUrlFunc(dependency.Name),
 			//Progress:      os.Stdout,
 			ReferenceName: dependency.GitReference,
 			Depth:         1,
 			SingleBranch:  true,
 		})
+	if err != nil {
+		// do not leave a partial installation behind
+		os.RemoveAll(installPath)
+		return err
+	}
 
-	return err
+	// if this version is locked, verify we installed the locked commit
+	if err := i.verifyLockedCommit(dependency, installPath); err != nil {
+		os.RemoveAll(installPath)
+		return err
+	}
+
+	// if we are updating, remove any other installed versions of this major version
+	if i.updating && dependency.Version != nil {
+		return i.deleteOtherVersions(dependency)
+	}
+	return nil
+}
+
+// verifyLockedCommit returns an error if the lock file contains the same version of the dependency
+// but the installed commit is different, i.e. the version tag has been moved since the lock file was written
+func (i *ModInstaller) verifyLockedCommit(dependency *ResolvedModRef, installPath string) error {
+	if i.updating || i.workspaceLock == nil {
+		return nil
+	}
+	locked, ok := i.workspaceLock.Mods[dependency.Name]
+	if !ok || locked.Version != dependency.GitReference.Short() {
+		return nil
+	}
+	commit, err := getInstalledCommit(installPath)
+	if err != nil {
+		return err
+	}
+	if commit != locked.Commit {
+		return fmt.Errorf("dependency %s %s resolved to commit %s but the lock file requires commit %s", dependency.Name, locked.Version, commit, locked.Commit)
+	}
+	return nil
+}
+
+// writeResolvedVersions updates the requires block of the workspace mod file with the
+// versions resolved for each of its direct dependencies
+func (i *ModInstaller) writeResolvedVersions(workspaceMod *modconfig.Mod) error {
+	if workspaceMod.Requires == nil {
+		return nil
+	}
+	versions := make(map[string]string)
+	for _, modVersion := range workspaceMod.Requires.Mods {
+		versions[modVersion.Name] = modVersion.VersionString
+		for _, dep := range i.InstalledDependencies {
+			if dep.Name == modVersion.Name && dep.Version != nil {
+				versions[modVersion.Name] = dep.GitReference.Short()
+			}
+		}
+	}
+	if err := setModFileRequirements(i.workspacePath, versions); err != nil {
+		return err
+	}
+	return i.writeWorkspaceLock()
+}
+
+// writeWorkspaceLock adds all installed git dependencies to the workspace lock and saves it
+func (i *ModInstaller) writeWorkspaceLock() error {
+	if i.workspaceLock == nil {
+		i.workspaceLock = NewWorkspaceLock(i.workspacePath)
+	}
+	for _, dep := range i.InstalledDependencies {
+		// only versioned git dependencies are locked
+		if dep.FilePath != "" || dep.Version == nil {
+			continue
+		}
+		if err := i.workspaceLock.lockDependency(dep, filepath.Join(i.ModsDir, dep.FullName())); err != nil {
+			return err
+		}
+	}
+	return i.workspaceLock.Save()
 }
 
 func (i *ModInstaller) InstallReport() string {
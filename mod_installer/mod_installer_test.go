@@ -0,0 +1,377 @@
+package mod_installer
+
+import (
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/otiai10/copy"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/steampipeconfig/parse"
+)
+
+const testModName = "github.com/test/m1"
+
+type modInstallerTest struct {
+	// tags to create in the fixture repo before running the test
+	tags []string
+	// existing requirement of the workspace mod
+	requires string
+	// mod refs to install - if empty, UpdateMods is called
+	install []string
+	// tags created in the fixture repo after the install, before running update
+	updateTags []string
+	// expected installed versions
+	expected []string
+	// expected version in the workspace mod requires block
+	expectedVersion string
+}
+
+var testCasesModInstaller = map[string]modInstallerTest{
+	"install latest": {
+		tags:            []string{"v1.0", "v1.1", "v2.0"},
+		install:         []string{testModName},
+		expected:        []string{"github.com/test/m1@v2.0"},
+		expectedVersion: "v2.0",
+	},
+	"install major version": {
+		tags:            []string{"v1.0", "v1.1", "v2.0"},
+		install:         []string{testModName + "@1"},
+		expected:        []string{"github.com/test/m1@v1.1"},
+		expectedVersion: "v1.1",
+	},
+	"install workspace dependencies": {
+		tags:            []string{"v1.0", "v1.1", "v2.0"},
+		requires:        "v1.0",
+		expected:        []string{"github.com/test/m1@v1.1"},
+		expectedVersion: "v1.1",
+	},
+	"update": {
+		tags:            []string{"v1.0"},
+		install:         []string{testModName + "@1"},
+		updateTags:      []string{"v1.2", "v2.0"},
+		expected:        []string{"github.com/test/m1@v1.2"},
+		expectedVersion: "v1.2",
+	},
+	"install patch version": {
+		tags:            []string{"v1.0.0", "v1.0.1", "v2.0.0"},
+		install:         []string{testModName + "@1"},
+		expected:        []string{"github.com/test/m1@v1.0.1"},
+		expectedVersion: "v1.0.1",
+	},
+	"update patch version": {
+		tags:            []string{"v1.0.0"},
+		install:         []string{testModName + "@1"},
+		updateTags:      []string{"v1.0.1"},
+		expected:        []string{"github.com/test/m1@v1.0.1"},
+		expectedVersion: "v1.0.1",
+	},
+	"install workspace patch dependencies": {
+		tags:            []string{"v1.0.0", "v1.0.1"},
+		requires:        "v1.0.0",
+		expected:        []string{"github.com/test/m1@v1.0.1"},
+		expectedVersion: "v1.0.1",
+	},
+}
+
+func TestModInstaller(t *testing.T) {
+	for name, test := range testCasesModInstaller {
+		tmpDir, err := ioutil.TempDir("", "mod_installer_test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(tmpDir)
+
+		// create the fixture repo and the workspace
+		repoPath := filepath.Join(tmpDir, "repos", testModName)
+		repo, err := createTestRepo(repoPath, "test_data/mods/m1", test.tags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		workspacePath := filepath.Join(tmpDir, "workspace")
+		if err := createTestWorkspace(workspacePath, test.requires); err != nil {
+			t.Fatal(err)
+		}
+
+		installer := newTestModInstaller(workspacePath, tmpDir)
+		if len(test.install) > 0 {
+			err = installer.GetMods(test.install)
+		} else {
+			err = installer.InstallWorkspaceDependencies()
+		}
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED with unexpected error: %v", name, err)
+			continue
+		}
+
+		if len(test.updateTags) > 0 {
+			if err := tagTestRepo(repo, test.updateTags); err != nil {
+				t.Fatal(err)
+			}
+			if err := newTestModInstaller(workspacePath, tmpDir).UpdateMods(nil); err != nil {
+				t.Errorf("Test: '%s'' FAILED with unexpected error: %v", name, err)
+				continue
+			}
+		}
+
+		installed, err := installer.ListInstalledMods()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var installedNames []string
+		for _, m := range installed {
+			installedNames = append(installedNames, m.String())
+		}
+		if strings.Join(installedNames, ",") != strings.Join(test.expected, ",") {
+			t.Errorf("Test: '%s'' FAILED : expected installed mods %v, got %v", name, test.expected, installedNames)
+		}
+
+		// verify the installed files are those of the expected tag
+		for _, m := range installed {
+			installedTag, err := ioutil.ReadFile(filepath.Join(installer.ModsDir, m.String(), "version.txt"))
+			if err != nil || string(installedTag) != test.expectedVersion {
+				t.Errorf("Test: '%s'' FAILED : expected %s to contain the files of tag %s, got %s", name, m, test.expectedVersion, installedTag)
+			}
+		}
+
+		workspaceMod, err := parse.ParseModDefinition(workspacePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		modVersion := workspaceMod.Requires.GetModVersion(testModName)
+		if modVersion == nil || modVersion.VersionString != test.expectedVersion {
+			t.Errorf("Test: '%s'' FAILED : expected requires version %s, got %v", name, test.expectedVersion, modVersion)
+		}
+
+		workspaceLock, err := LoadWorkspaceLock(workspacePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if locked, ok := workspaceLock.Mods[testModName]; !ok || locked.Version != test.expectedVersion {
+			t.Errorf("Test: '%s'' FAILED : expected locked version %s, got %v", name, test.expectedVersion, locked)
+		}
+
+		// now uninstall
+		if err := installer.UninstallMods([]string{testModName}); err != nil {
+			t.Errorf("Test: '%s'' FAILED with unexpected uninstall error: %v", name, err)
+			continue
+		}
+		installed, _ = installer.ListInstalledMods()
+		if len(installed) != 0 {
+			t.Errorf("Test: '%s'' FAILED : expected no installed mods after uninstall, got %v", name, installed)
+		}
+		workspaceMod, _ = parse.ParseModDefinition(workspacePath)
+		if workspaceMod.Requires != nil && workspaceMod.Requires.GetModVersion(testModName) != nil {
+			t.Errorf("Test: '%s'' FAILED : expected requirement to be removed after uninstall", name)
+		}
+		workspaceLock, _ = LoadWorkspaceLock(workspacePath)
+		if !workspaceLock.Empty() {
+			t.Errorf("Test: '%s'' FAILED : expected lock to be empty after uninstall", name)
+		}
+	}
+}
+
+func TestWorkspaceLock(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "workspace_lock_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	repoPath := filepath.Join(tmpDir, "repos", testModName)
+	repo, err := createTestRepo(repoPath, "test_data/mods/m1", []string{"v1.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspacePath := filepath.Join(tmpDir, "workspace")
+	if err := createTestWorkspace(workspacePath, "v1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := newTestModInstaller(workspacePath, tmpDir).InstallWorkspaceDependencies(); err != nil {
+		t.Fatal(err)
+	}
+
+	workspaceLock, err := LoadWorkspaceLock(workspacePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if warnings, err := workspaceLock.Validate(); err != nil || len(warnings) > 0 {
+		t.Errorf("expected lock to validate, got warnings %v, error %v", warnings, err)
+	}
+
+	// a newer version is released - a clean install should still use the locked version
+	if err := tagTestRepo(repo, []string{"v1.1"}); err != nil {
+		t.Fatal(err)
+	}
+	os.RemoveAll(constants.WorkspaceModPath(workspacePath))
+	if err := newTestModInstaller(workspacePath, tmpDir).InstallWorkspaceDependencies(); err != nil {
+		t.Fatal(err)
+	}
+	installed, _ := newTestModInstaller(workspacePath, tmpDir).ListInstalledMods()
+	if len(installed) != 1 || installed[0].String() != "github.com/test/m1@v1.0" {
+		t.Errorf("expected locked version github.com/test/m1@v1.0 to be installed, got %v", installed)
+	}
+
+	// modifying the installed files should produce a warning
+	installPath := filepath.Join(constants.WorkspaceModPath(workspacePath), "github.com/test/m1@v1.0")
+	if err := ioutil.WriteFile(filepath.Join(installPath, "query.sp"), []byte(`query "m1_q1"{ sql = "select 2" }`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if warnings, err := workspaceLock.Validate(); err != nil || len(warnings) != 1 {
+		t.Errorf("expected one warning for modified mod, got warnings %v, error %v", warnings, err)
+	}
+
+	// removing the installed mod should produce an error
+	os.RemoveAll(installPath)
+	if _, err := workspaceLock.Validate(); err == nil {
+		t.Errorf("expected error for locked mod which is not installed")
+	}
+}
+
+// newTestModInstaller creates a mod installer which installs from the fixture repos in tmpDir
+func newTestModInstaller(workspacePath, tmpDir string) *ModInstaller {
+	installer := NewModInstaller(workspacePath)
+	installer.gitUrlFunc = func(modName string) string {
+		return filepath.Join(tmpDir, "repos", modName)
+	}
+	return installer
+}
+
+// createTestRepo creates a git repo at repoPath containing the source mod, with a commit for each tag
+func createTestRepo(repoPath, source string, tags []string) (*git.Repository, error) {
+	if err := copy.Copy(source, repoPath); err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainInit(repoPath, false)
+	if err != nil {
+		return nil, err
+	}
+	return repo, tagTestRepo(repo, tags)
+}
+
+func tagTestRepo(repo *git.Repository, tags []string) error {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		// write a version file so each commit is distinct
+		if err := ioutil.WriteFile(filepath.Join(worktree.Filesystem.Root(), "version.txt"), []byte(tag), 0644); err != nil {
+			return err
+		}
+		if _, err := worktree.Add("."); err != nil {
+			return err
+		}
+		hash, err := worktree.Commit(tag, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := repo.CreateTag(tag, hash, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTestWorkspace creates a workspace mod, optionally requiring the test mod at the given version
+func createTestWorkspace(workspacePath, requires string) error {
+	if err := os.MkdirAll(workspacePath, 0755); err != nil {
+		return err
+	}
+	if _, err := InitWorkspaceMod(workspacePath); err != nil {
+		return err
+	}
+	if requires == "" {
+		return nil
+	}
+	return setModFileRequirements(workspacePath, map[string]string{testModName: requires})
+}
+
+func TestModInstallerLocalPath(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "mod_installer_local_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// create the local mod in the home dir, so it may be referenced using '~'
+	usr, err := user.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+	localDir, err := ioutil.TempDir(usr.HomeDir, "mod_installer_local_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(localDir)
+	localPath := filepath.Join(localDir, "m1")
+	if err := copy.Copy("test_data/mods/m1", localPath); err != nil {
+		t.Fatal(err)
+	}
+	localRef := "file:~/" + filepath.Base(localDir) + "/m1"
+	workspacePath := filepath.Join(tmpDir, "workspace")
+	if err := createTestWorkspace(workspacePath, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	installer := newTestModInstaller(workspacePath, tmpDir)
+	if err := installer.GetMods([]string{testModName + "@" + localRef}); err != nil {
+		t.Fatalf("unexpected error installing local mod: %v", err)
+	}
+	if len(installer.InstalledDependencies) != 1 || installer.InstalledDependencies[0].FilePath != localPath {
+		t.Errorf("expected local mod to be installed from %s, got %v", localPath, installer.InstalledDependencies)
+	}
+
+	// the requirement should be written as specified
+	workspaceMod, err := parse.ParseModDefinition(workspacePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modVersion := workspaceMod.Requires.GetModVersion(testModName)
+	if modVersion == nil || modVersion.VersionString != localRef {
+		t.Errorf("expected requires version %s, got %v", localRef, modVersion)
+	}
+
+	// a path which does not exist should fail
+	if err := newTestModInstaller(workspacePath, tmpDir).GetMods([]string{testModName + "@file:~/" + filepath.Base(localDir) + "/missing"}); err == nil {
+		t.Errorf("expected error installing a local mod which does not exist")
+	}
+}
+
+func TestHashModContent(t *testing.T) {
+	// the same concatenated paths and contents, split differently across files, must not hash the same
+	fileSets := []map[string]string{
+		{"ab": "c"},
+		{"a": "bc"},
+		{"a": "b", "b": "c"},
+	}
+	hashes := make(map[string]int)
+	for i, files := range fileSets {
+		dir, err := ioutil.TempDir("", "mod_hash")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		for name, content := range files {
+			if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		hash, err := hashModContent(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if previous, ok := hashes[hash]; ok {
+			t.Errorf("Test: 'hash mod content'' FAILED : file sets %d and %d have the same hash", previous, i)
+		}
+		hashes[hash] = i
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	goVersion "github.com/hashicorp/go-version"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
 )
 
 // ModRef is a struct to represent an unresolved mod reference
@@ -39,7 +41,7 @@ func NewModRef(modRef string) (*ModRef, error) {
 
 func (r *ModRef) setVersion(versionString string) {
 	if strings.HasPrefix(versionString, "file:") {
-		r.filePath = versionString
+		r.filePath = strings.TrimPrefix(versionString, "file:")
 		return
 	}
 	// does the verison parse as a semver version
@@ -51,3 +53,20 @@ func (r *ModRef) setVersion(versionString string) {
 	// otherwise assume it is a branch
 	r.branch = versionString
 }
+
+// ModVersion converts the mod ref into a ModVersion requirement
+// if no version was specified, the latest version is required
+func (r *ModRef) ModVersion() (*modconfig.ModVersion, error) {
+	versionString := "latest"
+	if split := strings.Split(r.raw, "@"); len(split) == 2 {
+		versionString = split[1]
+	}
+	res := &modconfig.ModVersion{
+		Name:          r.Name,
+		VersionString: versionString,
+	}
+	if diags := res.Initialise(); diags.HasErrors() {
+		return nil, plugin.DiagsToError("invalid mod version", diags)
+	}
+	return res, nil
+}
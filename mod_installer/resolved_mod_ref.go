@@ -2,6 +2,7 @@ package mod_installer
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/go-git/go-git/v5/plumbing"
 	goVersion "github.com/hashicorp/go-version"
@@ -56,9 +57,13 @@ func (r *ResolvedModRef) SetGitReference(modVersion *modconfig.ModVersion) {
 }
 
 // FullName returns name in the format <dependency name>@v<dependencyVersion>
+// (or <dependency name>@<branch> for a branch dependency)
+// the full version is used, so each patch version is installed into its own folder
 func (r *ResolvedModRef) FullName() string {
-	segments := r.Version.Segments()
-	return fmt.Sprintf("%s@v%d.%d", r.Name, segments[0], segments[1])
+	if r.Version == nil {
+		return fmt.Sprintf("%s@%s", r.Name, r.GitReference.Short())
+	}
+	return fmt.Sprintf("%s@v%s", r.Name, strings.TrimPrefix(r.Version.Original(), "v"))
 }
 
 // SatisfiesVersionConstraint return whether this resolved ref satisfies a version constraint
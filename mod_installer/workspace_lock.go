@@ -0,0 +1,215 @@
+package mod_installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/utils"
+)
+
+// LockedMod is a struct representing the resolved version of a mod dependency
+type LockedMod struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Commit      string `json:"commit"`
+	ContentHash string `json:"content_hash"`
+}
+
+// WorkspaceLock is a struct representing the workspace lock file
+// this records the resolved version, commit and content hash of every installed mod dependency
+type WorkspaceLock struct {
+	Mods map[string]*LockedMod `json:"mods"`
+
+	workspacePath string
+}
+
+func NewWorkspaceLock(workspacePath string) *WorkspaceLock {
+	return &WorkspaceLock{
+		Mods:          make(map[string]*LockedMod),
+		workspacePath: workspacePath,
+	}
+}
+
+// LoadWorkspaceLock loads the lock file from the workspace folder
+// if there is no lock file, an empty lock is returned
+func LoadWorkspaceLock(workspacePath string) (*WorkspaceLock, error) {
+	lockPath := constants.WorkspaceLockPath(workspacePath)
+	if !helpers.FileExists(lockPath) {
+		return NewWorkspaceLock(workspacePath), nil
+	}
+
+	data, err := ioutil.ReadFile(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	res := NewWorkspaceLock(workspacePath)
+	if err := json.Unmarshal(data, res); err != nil {
+		log.Println("[ERROR]", "Error while reading workspace lock file", err)
+		return nil, fmt.Errorf("failed to parse %s: %s", lockPath, err.Error())
+	}
+	if res.Mods == nil {
+		res.Mods = make(map[string]*LockedMod)
+	}
+	return res, nil
+}
+
+// Empty returns whether the lock contains no mods
+func (l *WorkspaceLock) Empty() bool {
+	return len(l.Mods) == 0
+}
+
+// Save writes the lock file to the workspace folder
+// if the lock is empty, any existing lock file is deleted
+func (l *WorkspaceLock) Save() error {
+	lockPath := constants.WorkspaceLockPath(l.workspacePath)
+	if l.Empty() {
+		if helpers.FileExists(lockPath) {
+			return os.Remove(lockPath)
+		}
+		return nil
+	}
+	content, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(lockPath, content, 0644)
+}
+
+// LockedModNames returns the names of all locked mods, sorted alphabetically
+func (l *WorkspaceLock) LockedModNames() []string {
+	var res []string
+	for name := range l.Mods {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Validate verifies the installed mods match the lock file
+// an error is returned if any locked mod is not installed or is installed from a different commit,
+// and a warning is returned for each locked mod whose installed files have been modified
+func (l *WorkspaceLock) Validate() (warnings []string, err error) {
+	modsDir := constants.WorkspaceModPath(l.workspacePath)
+
+	var failures []string
+	for _, name := range l.LockedModNames() {
+		locked := l.Mods[name]
+		installPath := filepath.Join(modsDir, locked.installedName())
+		if _, err := os.Stat(installPath); os.IsNotExist(err) {
+			failures = append(failures, fmt.Sprintf("%s %s is not installed", name, locked.Version))
+			continue
+		}
+		commit, err := getInstalledCommit(installPath)
+		if err != nil {
+			return nil, err
+		}
+		if commit != locked.Commit {
+			failures = append(failures, fmt.Sprintf("%s %s is installed from commit %s but the lock file requires %s", name, locked.Version, commit, locked.Commit))
+			continue
+		}
+		contentHash, err := hashModContent(installPath)
+		if err != nil {
+			return nil, err
+		}
+		if contentHash != locked.ContentHash {
+			warnings = append(warnings, fmt.Sprintf("installed files of mod dependency %s %s have been modified", name, locked.Version))
+		}
+	}
+
+	if len(failures) > 0 {
+		var failureErrors []error
+		for _, f := range failures {
+			failureErrors = append(failureErrors, errors.New(f))
+		}
+		err = utils.CombineErrorsWithPrefix("installed mods do not match the workspace lock file - run 'steampipe mod install' to install the locked versions", failureErrors...)
+	}
+	return warnings, err
+}
+
+// lockDependency adds the installed dependency to the lock
+func (l *WorkspaceLock) lockDependency(dependency *ResolvedModRef, installPath string) error {
+	commit, err := getInstalledCommit(installPath)
+	if err != nil {
+		return err
+	}
+	contentHash, err := hashModContent(installPath)
+	if err != nil {
+		return err
+	}
+	l.Mods[dependency.Name] = &LockedMod{
+		Name:        dependency.Name,
+		Version:     dependency.GitReference.Short(),
+		Commit:      commit,
+		ContentHash: contentHash,
+	}
+	return nil
+}
+
+// installedName returns the name of the installation folder of the locked mod
+func (m *LockedMod) installedName() string {
+	ref := &ResolvedModRef{Name: m.Name}
+	if v, err := versionFromTag(m.Version); err == nil {
+		ref.Version = v
+	}
+	return ref.FullName()
+}
+
+// getInstalledCommit returns the commit hash of the HEAD of the installed mod repo
+func getInstalledCommit(installPath string) (string, error) {
+	repo, err := git.PlainOpen(installPath)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return head.Hash().String(), nil
+}
+
+// hashModContent returns a sha256 hash of the relative paths and contents of all files of an installed mod,
+// excluding the git folder
+func hashModContent(installPath string) (string, error) {
+	hash := sha256.New()
+	err := filepath.Walk(installPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relPath, err := filepath.Rel(installPath, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		// terminate the path and size with NUL bytes, so the boundary between files is unambiguous
+		fmt.Fprintf(hash, "%s\x00%d\x00", filepath.ToSlash(relPath), info.Size())
+		_, err = io.Copy(hash, f)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
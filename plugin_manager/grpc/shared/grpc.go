@@ -19,6 +19,18 @@ func (c *GRPCClient) Get(req *pb.GetRequest) (*pb.GetResponse, error) {
 	return c.client.Get(c.ctx, req)
 }
 
+func (c *GRPCClient) List(req *pb.ListRequest) (*pb.ListResponse, error) {
+	return c.client.List(c.ctx, req)
+}
+
+func (c *GRPCClient) Restart(req *pb.RestartRequest) (*pb.RestartResponse, error) {
+	return c.client.Restart(c.ctx, req)
+}
+
+func (c *GRPCClient) Stop(req *pb.StopRequest) (*pb.StopResponse, error) {
+	return c.client.Stop(c.ctx, req)
+}
+
 func (c *GRPCClient) Shutdown(req *pb.ShutdownRequest) (*pb.ShutdownResponse, error) {
 	return c.client.Shutdown(c.ctx, req)
 }
@@ -33,6 +45,18 @@ func (m *GRPCServer) Get(_ context.Context, req *pb.GetRequest) (*pb.GetResponse
 	return m.Impl.Get(req)
 }
 
+func (m *GRPCServer) List(_ context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+	return m.Impl.List(req)
+}
+
+func (m *GRPCServer) Restart(_ context.Context, req *pb.RestartRequest) (*pb.RestartResponse, error) {
+	return m.Impl.Restart(req)
+}
+
+func (m *GRPCServer) Stop(_ context.Context, req *pb.StopRequest) (*pb.StopResponse, error) {
+	return m.Impl.Stop(req)
+}
+
 func (m *GRPCServer) Shutdown(_ context.Context, req *pb.ShutdownRequest) (*pb.ShutdownResponse, error) {
 	return m.Impl.Shutdown(req)
 }
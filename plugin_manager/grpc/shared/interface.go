@@ -26,6 +26,9 @@ var Handshake = plugin.HandshakeConfig{
 // PluginManager is the interface for the plugin manager service
 type PluginManager interface {
 	Get(req *pb.GetRequest) (*pb.GetResponse, error)
+	List(req *pb.ListRequest) (*pb.ListResponse, error)
+	Restart(req *pb.RestartRequest) (*pb.RestartResponse, error)
+	Stop(req *pb.StopRequest) (*pb.StopResponse, error)
 	Shutdown(req *pb.ShutdownRequest) (*pb.ShutdownResponse, error)
 }
 
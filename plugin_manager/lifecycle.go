@@ -125,6 +125,16 @@ func GetPluginManager() (pluginshared.PluginManager, error) {
 	return getPluginManager(true)
 }
 
+// GetRunningPluginManager connects to the plugin manager if it is running, without starting it
+// if the plugin manager is not running, nil is returned
+func GetRunningPluginManager() (pluginshared.PluginManager, error) {
+	state, err := loadPluginManagerState(true)
+	if err != nil || state == nil {
+		return nil, err
+	}
+	return NewPluginManagerClient(state)
+}
+
 // getPluginManager determines whether the plugin manager is running
 // if not,and if startIfNeeded is true, it starts the manager
 // it then returns a plugin manager client
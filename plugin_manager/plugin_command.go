@@ -0,0 +1,102 @@
+package plugin_manager
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/steampipe/constants"
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
+	"github.com/turbot/steampipe/utils"
+)
+
+// pluginCommand builds the command to start the plugin process for a connection
+//
+// if the connection has memory or nice limits, the plugin is started by a shell which applies them
+// before exec-ing the plugin - so the plugin process (and its pid) replaces the shell
+// the open files limit is passed to the plugin in the environment, as the plugin SDK sets its own open files limit
+func pluginCommand(pluginPath string, connectionConfig *pb.ConnectionConfig) *exec.Cmd {
+	var cmd *exec.Cmd
+	if script := pluginLimitsScript(connectionConfig.Limits); script != "" {
+		// pass the plugin path as $0 so it does not need quoting
+		cmd = exec.Command("/bin/sh", "-c", script, pluginPath)
+	} else {
+		cmd = exec.Command(pluginPath)
+	}
+	cmd.Env = pluginEnv(os.Environ(), pluginConnectionEnv(connectionConfig))
+	return cmd
+}
+
+// pluginConnectionEnv returns the connection env, with the open files limit (if any) added
+func pluginConnectionEnv(connectionConfig *pb.ConnectionConfig) map[string]string {
+	limits := connectionConfig.Limits
+	if limits == nil || limits.MaxOpenFiles <= 0 {
+		return connectionConfig.Env
+	}
+	env := make(map[string]string, len(connectionConfig.Env)+1)
+	for name, value := range connectionConfig.Env {
+		env[name] = value
+	}
+	env[constants.EnvULimit] = strconv.FormatInt(limits.MaxOpenFiles, 10)
+	return env
+}
+
+// pluginLimitsScript returns a shell script which applies the memory and nice limits and execs the plugin
+// if there are no such limits, an empty string is returned
+//
+// NOTE: the memory limit is best-effort - it limits the virtual address space of the process (ulimit -v),
+// which for a Go program is larger than the memory actually used, and is not supported on all platforms
+func pluginLimitsScript(limits *pb.ConnectionLimits) string {
+	if limits == nil {
+		return ""
+	}
+	var statements []string
+	if limits.MaxMemoryMb > 0 {
+		// ulimit -v is in KB
+		statements = append(statements, fmt.Sprintf("ulimit -v %d", limits.MaxMemoryMb*1024))
+	}
+	execStatement := `exec "$0"`
+	if limits.Nice != 0 {
+		execStatement = fmt.Sprintf(`exec nice -n %d "$0"`, limits.Nice)
+	} else if len(statements) == 0 {
+		return ""
+	}
+	return strings.Join(append(statements, execStatement), " && ")
+}
+
+// pluginEnv returns the environment for a plugin process - the connection env is added to the steampipe env,
+// overriding any variables which are already set
+func pluginEnv(environ []string, connectionEnv map[string]string) []string {
+	env := make([]string, 0, len(environ)+len(connectionEnv))
+	for _, e := range environ {
+		name := strings.SplitN(e, "=", 2)[0]
+		if _, ok := connectionEnv[name]; !ok {
+			env = append(env, e)
+		}
+	}
+	// add connection env in a consistent order
+	var names []string
+	for name := range connectionEnv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, fmt.Sprintf("%s=%s", name, connectionEnv[name]))
+	}
+	return env
+}
+
+// connectionConfigHash returns a hash of the connection config - this identifies the config a plugin was started with
+func connectionConfigHash(connectionConfig *pb.ConnectionConfig) string {
+	str := fmt.Sprintf("%s%s", connectionConfig.Plugin, connectionConfig.Config)
+	if len(connectionConfig.Env) > 0 {
+		str += strings.Join(pluginEnv(nil, connectionConfig.Env), "\n")
+	}
+	if limits := connectionConfig.Limits; limits != nil {
+		str += fmt.Sprintf("%d/%d/%d", limits.MaxMemoryMb, limits.Nice, limits.MaxOpenFiles)
+	}
+	return utils.GetMD5Hash(str)
+}
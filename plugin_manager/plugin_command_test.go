@@ -0,0 +1,104 @@
+package plugin_manager
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
+)
+
+type pluginLimitsScriptTest struct {
+	limits   *pb.ConnectionLimits
+	expected string
+}
+
+var testCasesPluginLimitsScript = map[string]pluginLimitsScriptTest{
+	"no limits": {
+		limits:   nil,
+		expected: "",
+	},
+	"zero limits": {
+		limits:   &pb.ConnectionLimits{},
+		expected: "",
+	},
+	"memory and open files": {
+		limits:   &pb.ConnectionLimits{MaxMemoryMb: 512, MaxOpenFiles: 1024},
+		expected: `ulimit -v 524288 && exec "$0"`,
+	},
+	"open files only": {
+		limits:   &pb.ConnectionLimits{MaxOpenFiles: 1024},
+		expected: "",
+	},
+	"nice only": {
+		limits:   &pb.ConnectionLimits{Nice: 10},
+		expected: `exec nice -n 10 "$0"`,
+	},
+}
+
+func TestPluginLimitsScript(t *testing.T) {
+	for name, test := range testCasesPluginLimitsScript {
+		if script := pluginLimitsScript(test.limits); script != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %q, got %q", name, test.expected, script)
+		}
+	}
+}
+
+type pluginEnvTest struct {
+	environ       []string
+	connectionEnv map[string]string
+	expected      []string
+}
+
+var testCasesPluginEnv = map[string]pluginEnvTest{
+	"no connection env": {
+		environ:  []string{"HOME=/home/steampipe", "AWS_PROFILE=default"},
+		expected: []string{"HOME=/home/steampipe", "AWS_PROFILE=default"},
+	},
+	"connection env overrides": {
+		environ:       []string{"HOME=/home/steampipe", "AWS_PROFILE=default"},
+		connectionEnv: map[string]string{"HTTPS_PROXY": "http://proxy:3128", "AWS_PROFILE": "prod"},
+		expected:      []string{"HOME=/home/steampipe", "AWS_PROFILE=prod", "HTTPS_PROXY=http://proxy:3128"},
+	},
+}
+
+func TestPluginEnv(t *testing.T) {
+	for name, test := range testCasesPluginEnv {
+		if env := pluginEnv(test.environ, test.connectionEnv); !reflect.DeepEqual(env, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, env)
+		}
+	}
+}
+
+type pluginConnectionEnvTest struct {
+	connectionConfig *pb.ConnectionConfig
+	expected         map[string]string
+}
+
+var testCasesPluginConnectionEnv = map[string]pluginConnectionEnvTest{
+	"no limits": {
+		connectionConfig: &pb.ConnectionConfig{Env: map[string]string{"AWS_PROFILE": "prod"}},
+		expected:         map[string]string{"AWS_PROFILE": "prod"},
+	},
+	"open files limit": {
+		connectionConfig: &pb.ConnectionConfig{
+			Env:    map[string]string{"AWS_PROFILE": "prod"},
+			Limits: &pb.ConnectionLimits{MaxOpenFiles: 1024},
+		},
+		expected: map[string]string{"AWS_PROFILE": "prod", "STEAMPIPE_ULIMIT": "1024"},
+	},
+	"open files limit overrides env": {
+		connectionConfig: &pb.ConnectionConfig{
+			Env:    map[string]string{"STEAMPIPE_ULIMIT": "8192"},
+			Limits: &pb.ConnectionLimits{MaxOpenFiles: 1024},
+		},
+		expected: map[string]string{"STEAMPIPE_ULIMIT": "1024"},
+	},
+}
+
+func TestPluginConnectionEnv(t *testing.T) {
+	for name, test := range testCasesPluginConnectionEnv {
+		if env := pluginConnectionEnv(test.connectionConfig); !reflect.DeepEqual(env, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, env)
+		}
+	}
+}
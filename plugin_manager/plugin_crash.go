@@ -0,0 +1,207 @@
+package plugin_manager
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+)
+
+const (
+	// if a plugin crashes this many times within pluginCrashWindow, the connection is marked as failed
+	maxPluginCrashes  = 5
+	pluginCrashWindow = 5 * time.Minute
+	// the delay before restarting a crashed plugin doubles with each crash, between these bounds
+	pluginRestartMinBackoff = time.Second
+	pluginRestartMaxBackoff = time.Minute
+	// the interval at which plugin clients are checked for exit
+	pluginExitCheckInterval = time.Second
+	// the number of lines of plugin stderr output to retain
+	pluginStderrLines = 20
+)
+
+// pluginCrashState records the recent crashes of the plugin for a connection
+type pluginCrashState struct {
+	crashes []time.Time
+	// when the plugin will be restarted
+	restartAt time.Time
+	// if set, the plugin has crashed too many times and will not be restarted
+	failed bool
+	reason string
+}
+
+// addCrash records a crash at 'now', discarding crashes outside the crash window
+// it returns the delay before the plugin should be restarted
+func (s *pluginCrashState) addCrash(now time.Time) time.Duration {
+	var recent []time.Time
+	for _, t := range s.crashes {
+		if now.Sub(t) < pluginCrashWindow {
+			recent = append(recent, t)
+		}
+	}
+	s.crashes = append(recent, now)
+
+	backoff := restartBackoff(len(s.crashes))
+	s.restartAt = now.Add(backoff)
+	return backoff
+}
+
+// restartBackoff returns the delay before restarting a plugin which has crashed 'crashes' times
+func restartBackoff(crashes int) time.Duration {
+	backoff := pluginRestartMinBackoff
+	for i := 1; i < crashes && backoff < pluginRestartMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > pluginRestartMaxBackoff {
+		backoff = pluginRestartMaxBackoff
+	}
+	return backoff
+}
+
+// stderrBuffer is an io.Writer which retains the last pluginStderrLines lines written to it
+type stderrBuffer struct {
+	lines   []string
+	partial string
+	mut     sync.Mutex
+}
+
+func (b *stderrBuffer) Write(p []byte) (int, error) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	parts := strings.Split(b.partial+string(p), "\n")
+	// the last part is not terminated by a newline - keep it until it is
+	b.partial = parts[len(parts)-1]
+	b.lines = append(b.lines, parts[:len(parts)-1]...)
+	if len(b.lines) > pluginStderrLines {
+		b.lines = b.lines[len(b.lines)-pluginStderrLines:]
+	}
+	return len(p), nil
+}
+
+// String returns the retained output
+func (b *stderrBuffer) String() string {
+	if b == nil {
+		return ""
+	}
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	lines := b.lines
+	if b.partial != "" {
+		lines = append(lines, b.partial)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// watchPlugin waits for the plugin client to exit and, if the exit was not requested, handles the crash
+func (m *PluginManager) watchPlugin(connection string, client *plugin.Client, stderr *stderrBuffer) {
+	ticker := time.NewTicker(pluginExitCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.shutdownChan:
+			return
+		case <-ticker.C:
+			if client.Exited() {
+				m.onPluginExit(connection, client, stderr)
+				return
+			}
+		}
+	}
+}
+
+// onPluginExit is called when a plugin client exits
+// if the client is still in the Plugins map, the exit was not requested, i.e. the plugin crashed
+func (m *PluginManager) onPluginExit(connection string, client *plugin.Client, stderr *stderrBuffer) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if m.isShutdown() {
+		return
+	}
+	if p, ok := m.Plugins[connection]; !ok || p.client != client {
+		// the plugin was stopped (or replaced) by the plugin manager
+		return
+	}
+	delete(m.Plugins, connection)
+	m.savePluginsState()
+
+	output := stderr.String()
+	log.Printf("[WARN] plugin for connection '%s' crashed, output: %s", connection, output)
+	m.handlePluginCrash(connection, output)
+}
+
+// handlePluginCrash records a crash of the plugin for a connection and either schedules a restart
+// or, if the plugin has crashed too often, marks the connection as failed
+// NOTE: the caller must hold the mutex
+func (m *PluginManager) handlePluginCrash(connection, output string) {
+	crashState, ok := m.crashes[connection]
+	if !ok {
+		crashState = &pluginCrashState{}
+		m.crashes[connection] = crashState
+	}
+	backoff := crashState.addCrash(time.Now())
+
+	if len(crashState.crashes) >= maxPluginCrashes {
+		crashState.failed = true
+		crashState.reason = fmt.Sprintf("plugin for connection '%s' crashed %d times in %s - run 'steampipe plugin restart %s' to restart it", connection, len(crashState.crashes), pluginCrashWindow, connection)
+		if output != "" {
+			crashState.reason = fmt.Sprintf("%s - last output:\n%s", crashState.reason, output)
+		}
+		log.Printf("[WARN] %s - not restarting", crashState.reason)
+		return
+	}
+
+	log.Printf("[TRACE] restarting plugin for connection '%s' in %s", connection, backoff)
+	time.AfterFunc(backoff, func() { m.restartCrashedPlugin(connection) })
+}
+
+// restartCrashedPlugin restarts the plugin for a connection after a crash, unless it has already been restarted
+func (m *PluginManager) restartCrashedPlugin(connection string) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if m.isShutdown() {
+		return
+	}
+	if crashState, ok := m.crashes[connection]; !ok || crashState.failed {
+		// the crash state has been cleared by a restart, or the connection has failed
+		return
+	}
+	if _, ok := m.Plugins[connection]; ok {
+		return
+	}
+	if _, err := m.startAndStorePlugin(connection); err != nil {
+		log.Printf("[WARN] failed to restart plugin for connection '%s': %s", connection, err.Error())
+		m.handlePluginCrash(connection, err.Error())
+	}
+}
+
+// checkCrashState returns an error if the plugin for a connection has failed or is waiting to be restarted
+// NOTE: the caller must hold the mutex
+func (m *PluginManager) checkCrashState(connection string) error {
+	crashState, ok := m.crashes[connection]
+	if !ok {
+		return nil
+	}
+	if crashState.failed {
+		return fmt.Errorf("%s", crashState.reason)
+	}
+	if wait := time.Until(crashState.restartAt); wait > 0 {
+		return fmt.Errorf("plugin for connection '%s' crashed and will be restarted in %s", connection, wait.Round(time.Second))
+	}
+	return nil
+}
+
+func (m *PluginManager) isShutdown() bool {
+	select {
+	case <-m.shutdownChan:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,83 @@
+package plugin_manager
+
+import (
+	"testing"
+	"time"
+)
+
+type restartBackoffTest struct {
+	crashes  int
+	expected time.Duration
+}
+
+var testCasesRestartBackoff = map[string]restartBackoffTest{
+	"first crash": {
+		crashes:  1,
+		expected: time.Second,
+	},
+	"third crash": {
+		crashes:  3,
+		expected: 4 * time.Second,
+	},
+	"capped": {
+		crashes:  20,
+		expected: time.Minute,
+	},
+}
+
+func TestRestartBackoff(t *testing.T) {
+	for name, test := range testCasesRestartBackoff {
+		if backoff := restartBackoff(test.crashes); backoff != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s, got %s", name, test.expected, backoff)
+		}
+	}
+}
+
+type addCrashTest struct {
+	crashes  []time.Duration
+	expected int
+}
+
+var testCasesAddCrash = map[string]addCrashTest{
+	"no previous crashes": {
+		crashes:  nil,
+		expected: 1,
+	},
+	"recent crashes": {
+		crashes:  []time.Duration{time.Minute, 30 * time.Second},
+		expected: 3,
+	},
+	"old crashes are discarded": {
+		crashes:  []time.Duration{10 * time.Minute, 6 * time.Minute, time.Minute},
+		expected: 2,
+	},
+}
+
+func TestAddCrash(t *testing.T) {
+	now := time.Now()
+	for name, test := range testCasesAddCrash {
+		crashState := &pluginCrashState{}
+		for _, age := range test.crashes {
+			crashState.crashes = append(crashState.crashes, now.Add(-age))
+		}
+		crashState.addCrash(now)
+		if len(crashState.crashes) != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %d crashes, got %d", name, test.expected, len(crashState.crashes))
+		}
+	}
+}
+
+func TestStderrBuffer(t *testing.T) {
+	buffer := &stderrBuffer{}
+	for i := 0; i < pluginStderrLines+5; i++ {
+		buffer.Write([]byte("line\n"))
+	}
+	buffer.Write([]byte("partial"))
+	lines := len(buffer.lines)
+	if lines != pluginStderrLines {
+		t.Errorf("Test: 'stderr buffer'' FAILED : expected %d lines, got %d", pluginStderrLines, lines)
+	}
+	if expected := "line\npartial"; buffer.String()[len(buffer.String())-len(expected):] != expected {
+		t.Errorf("Test: 'stderr buffer'' FAILED : expected output to end with %q, got %q", expected, buffer.String())
+	}
+}
@@ -3,9 +3,10 @@ package plugin_manager
 import (
 	"fmt"
 	"log"
-	"os"
-	"os/exec"
+	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
@@ -17,8 +18,16 @@ import (
 )
 
 type runningPlugin struct {
-	client   *plugin.Client
-	reattach *pb.ReattachConfig
+	client     *plugin.Client
+	reattach   *pb.ReattachConfig
+	plugin     string
+	startTime  time.Time
+	lastUsed   time.Time
+	configHash string
+	// the path of the plugin executable
+	executable string
+	// the CPU time of the plugin process when it was last checked for activity
+	cpuTime time.Duration
 }
 
 // PluginManager is the real implementation of grpc.PluginManager
@@ -30,20 +39,48 @@ type PluginManager struct {
 	mut              sync.Mutex
 	connectionConfig map[string]*pb.ConnectionConfig
 	logger           hclog.Logger
+	// plugin processes which have not been used for this long are stopped - zero means never
+	idleTimeout time.Duration
+	// function returning the CPU time used by a process, used to detect plugin activity
+	cpuTimeFunc func(pid int) (time.Duration, error)
+	// function returning the number of open database sessions - idle plugins are only stopped when this returns zero
+	// (if it is not set, idle plugins are never stopped)
+	openSessionsFunc func() (int64, error)
+	// closed on shutdown to stop the idle plugin reaper and the plugin exit watchers
+	shutdownChan chan struct{}
+	// if set, the Plugins map is saved to the plugins state file whenever it changes
+	persistState bool
+	// the recent crashes of plugins, keyed by connection
+	crashes map[string]*pluginCrashState
 }
 
-func NewPluginManager(connectionConfig map[string]*pb.ConnectionConfig, logger hclog.Logger) *PluginManager {
+func NewPluginManager(connectionConfig map[string]*pb.ConnectionConfig, idleTimeout time.Duration, logger hclog.Logger) *PluginManager {
 	pluginManager := &PluginManager{
 		logger:           logger,
 		connectionConfig: connectionConfig,
 		Plugins:          make(map[string]runningPlugin),
+		idleTimeout:      idleTimeout,
+		shutdownChan:     make(chan struct{}),
+		crashes:          make(map[string]*pluginCrashState),
+		cpuTimeFunc:      processCPUTime,
 	}
 	return pluginManager
 }
 
+// SetOpenSessionsFunc sets the function used to check for open database sessions before stopping idle plugins
+func (m *PluginManager) SetOpenSessionsFunc(openSessionsFunc func() (int64, error)) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.openSessionsFunc = openSessionsFunc
+}
+
 // plugin interface functions
 
 func (m *PluginManager) Serve() {
+	if m.idleTimeout > 0 {
+		go m.reapIdlePlugins()
+	}
+
 	// create a plugin map, using ourselves as the implementation
 	pluginMap := map[string]plugin.Plugin{
 		pluginshared.PluginName: &pluginshared.PluginManagerPlugin{Impl: m},
@@ -83,6 +120,10 @@ func (m *PluginManager) Get(req *pb.GetRequest) (resp *pb.GetResponse, err error
 			// so the plugin id good
 			log.Printf("[TRACE] PluginManager found '%s' in map %v", req.Connection, m.Plugins)
 
+			// update the last used time
+			p.lastUsed = time.Now()
+			m.Plugins[req.Connection] = p
+
 			// return the reattach config
 			return &pb.GetResponse{
 				Reattach: reattach,
@@ -92,25 +133,25 @@ func (m *PluginManager) Get(req *pb.GetRequest) (resp *pb.GetResponse, err error
 		//  either the pid does not exist or the plugin has exited
 		// remove from map
 		delete(m.Plugins, req.Connection)
+		m.savePluginsState()
 		// update reason
 		reason = fmt.Sprintf("PluginManager found pid %d for connection '%s' in plugin map but plugin process does not exist - killing client and removing from map", reattach.Pid, req.Connection)
 	}
 
+	// if the plugin has crashed, it is either waiting to be restarted or has failed
+	if err := m.checkCrashState(req.Connection); err != nil {
+		return nil, err
+	}
+
 	// fall through to plugin startup
 	// log the startup reason
 	log.Printf("[TRACE] %s", reason)
 	// so we need to start the plugin
-	client, err := m.startPlugin(req)
+	reattach, err := m.startAndStorePlugin(req.Connection)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO ADD PLUGIN TO OUR STATE FILE - JUST SERIALISE THE Plugins map?
-
-	// store the client to our map
-	reattach := pb.NewReattachConfig(client.ReattachConfig())
-	m.Plugins[req.Connection] = runningPlugin{client: client, reattach: reattach}
-
 	log.Printf("[TRACE] PluginManager Get complete, returning reattach config with PID: %d", reattach.Pid)
 
 	// and return
@@ -118,6 +159,89 @@ func (m *PluginManager) Get(req *pb.GetRequest) (resp *pb.GetResponse, err error
 
 }
 
+// List returns the status of all plugin processes in the Plugins map, ordered by connection name
+func (m *PluginManager) List(req *pb.ListRequest) (resp *pb.ListResponse, err error) {
+	m.mut.Lock()
+	defer func() {
+		m.mut.Unlock()
+		if r := recover(); r != nil {
+			err = helpers.ToError(r)
+		}
+	}()
+
+	resp = &pb.ListResponse{}
+	for connection, p := range m.Plugins {
+		resp.Plugins = append(resp.Plugins, &pb.RunningPlugin{
+			Connection: connection,
+			Plugin:     p.plugin,
+			Pid:        p.reattach.Pid,
+			StartTime:  p.startTime.Unix(),
+			ConfigHash: p.configHash,
+			LastUsed:   p.lastUsed.Unix(),
+		})
+	}
+	sort.Slice(resp.Plugins, func(i, j int) bool {
+		return resp.Plugins[i].Connection < resp.Plugins[j].Connection
+	})
+	for connection, crashState := range m.crashes {
+		if crashState.failed {
+			if resp.FailedConnections == nil {
+				resp.FailedConnections = make(map[string]string)
+			}
+			resp.FailedConnections[connection] = crashState.reason
+		}
+	}
+	return resp, nil
+}
+
+// Restart kills the plugin process for the given connection (if it is running) and starts a new one
+//
+// database sessions which have already used the connection hold the reattach config of the killed plugin process,
+// so their next query against the connection fails - they must reconnect to use the new plugin process
+func (m *PluginManager) Restart(req *pb.RestartRequest) (resp *pb.RestartResponse, err error) {
+	log.Printf("[TRACE] PluginManager Restart connection '%s'", req.Connection)
+
+	m.mut.Lock()
+	defer func() {
+		m.mut.Unlock()
+		if r := recover(); r != nil {
+			err = helpers.ToError(r)
+		}
+	}()
+
+	if _, ok := m.connectionConfig[req.Connection]; !ok {
+		return nil, fmt.Errorf("no config loaded for connection %s", req.Connection)
+	}
+
+	m.killPlugin(req.Connection)
+	// an explicit restart clears any crash history, including a failed state
+	delete(m.crashes, req.Connection)
+
+	reattach, err := m.startAndStorePlugin(req.Connection)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RestartResponse{Reattach: reattach}, nil
+}
+
+// Stop kills the plugin process for the given connection and removes it from the Plugins map
+func (m *PluginManager) Stop(req *pb.StopRequest) (resp *pb.StopResponse, err error) {
+	log.Printf("[TRACE] PluginManager Stop connection '%s'", req.Connection)
+
+	m.mut.Lock()
+	defer func() {
+		m.mut.Unlock()
+		if r := recover(); r != nil {
+			err = helpers.ToError(r)
+		}
+	}()
+
+	if !m.killPlugin(req.Connection) {
+		return nil, fmt.Errorf("no plugin is running for connection %s", req.Connection)
+	}
+	return &pb.StopResponse{}, nil
+}
+
 func (m *PluginManager) SetConnectionConfigMap(configMap map[string]*pb.ConnectionConfig) {
 	m.mut.Lock()
 	defer m.mut.Unlock()
@@ -136,26 +260,327 @@ func (m *PluginManager) Shutdown(req *pb.ShutdownRequest) (resp *pb.ShutdownResp
 		}
 	}()
 
+	// stop the idle plugin reaper and the plugin exit watchers
+	select {
+	case <-m.shutdownChan:
+	default:
+		close(m.shutdownChan)
+	}
+
 	for _, p := range m.Plugins {
 		log.Printf("[TRACE] killing plugin %v", p)
 		p.client.Kill()
 	}
+	// all plugins are killed - there is nothing to reattach to
+	if m.persistState {
+		if err := deletePluginsState(); err != nil {
+			log.Printf("[WARN] failed to delete plugin manager plugins state: %s", err.Error())
+		}
+	}
 	return &pb.ShutdownResponse{}, nil
 }
 
-func (m *PluginManager) startPlugin(req *pb.GetRequest) (*plugin.Client, error) {
+// startAndStorePlugin starts the plugin for the given connection and adds it to the Plugins map
+// NOTE: the caller must hold the mutex
+func (m *PluginManager) startAndStorePlugin(connection string) (*pb.ReattachConfig, error) {
+	stderr := &stderrBuffer{}
+	client, pluginPath, err := m.startPlugin(connection, stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	// store the client to our map
+	connectionConfig := m.connectionConfig[connection]
+	reattach := pb.NewReattachConfig(client.ReattachConfig())
+	now := time.Now()
+	m.Plugins[connection] = runningPlugin{
+		client:     client,
+		reattach:   reattach,
+		plugin:     connectionConfig.Plugin,
+		startTime:  now,
+		lastUsed:   now,
+		configHash: connectionConfigHash(connectionConfig),
+		executable: pluginPath,
+	}
+	m.savePluginsState()
+	go m.watchPlugin(connection, client, stderr)
+	return reattach, nil
+}
+
+// killPlugin kills the plugin process for the given connection and removes it from the Plugins map
+// it returns whether there was a plugin in the map for the connection
+// NOTE: the caller must hold the mutex
+func (m *PluginManager) killPlugin(connection string) bool {
+	p, ok := m.Plugins[connection]
+	if !ok {
+		return false
+	}
+	log.Printf("[TRACE] killing plugin for connection '%s', pid %d", connection, p.reattach.Pid)
+	p.client.Kill()
+	delete(m.Plugins, connection)
+	m.savePluginsState()
+	return true
+}
+
+// RestorePluginsState loads the plugin processes started by a previous instance of the plugin manager
+// and reattaches to those which are still running with the current connection config
+// processes which cannot be reattached to, or which were started with outdated config, are killed
+// once this is called, the Plugins map is persisted whenever it changes
+func (m *PluginManager) RestorePluginsState() error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.persistState = true
+
+	state, err := loadPluginsState()
+	if err != nil {
+		return err
+	}
+	for connection, pluginState := range state {
+		if exists, _ := utils.PidExists(pluginState.Pid); !exists {
+			log.Printf("[TRACE] plugin process %d for connection '%s' is no longer running", pluginState.Pid, connection)
+			continue
+		}
+		// the pid may have been reused since the state was saved - never kill a process which is not the plugin
+		if err := verifyPluginProcess(pluginState.Pid, pluginState.Executable); err != nil {
+			log.Printf("[TRACE] not reattaching to process %d for connection '%s': %s", pluginState.Pid, connection, err.Error())
+			continue
+		}
+		client, err := m.reattachPlugin(connection, pluginState)
+		if err != nil {
+			log.Printf("[TRACE] not reattaching to plugin process %d for connection '%s': %s - killing it", pluginState.Pid, connection, err.Error())
+			killPluginProcess(pluginState.Pid)
+			continue
+		}
+		log.Printf("[TRACE] reattached to plugin process %d for connection '%s'", pluginState.Pid, connection)
+		m.Plugins[connection] = runningPlugin{
+			client:     client,
+			reattach:   pb.NewReattachConfig(client.ReattachConfig()),
+			plugin:     pluginState.Plugin,
+			startTime:  pluginState.StartTime,
+			lastUsed:   pluginState.LastUsed,
+			configHash: pluginState.ConfigHash,
+			executable: pluginState.Executable,
+		}
+		go m.watchPlugin(connection, client, nil)
+	}
+	// save the state to remove any plugins we did not reattach to
+	m.savePluginsState()
+	return nil
+}
+
+// reattachPlugin creates a client for a running plugin process started by a previous instance of the plugin manager
+func (m *PluginManager) reattachPlugin(connection string, pluginState *runningPluginState) (*plugin.Client, error) {
+	connectionConfig, ok := m.connectionConfig[connection]
+	if !ok {
+		return nil, fmt.Errorf("no config loaded for connection %s", connection)
+	}
+	if connectionConfigHash(connectionConfig) != pluginState.ConfigHash {
+		return nil, fmt.Errorf("connection config has changed")
+	}
+	reattach, err := pluginState.reattachConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  sdkshared.Handshake,
+		Plugins:          map[string]plugin.Plugin{pluginState.Plugin: &sdkshared.WrapperPlugin{}},
+		Reattach:         reattach,
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		Logger:           m.logger,
+	})
+	// verify we can connect to the plugin
+	if _, err := client.Client(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// savePluginsState saves the Plugins map to the plugins state file (if persistence is enabled)
+// NOTE: the caller must hold the mutex
+func (m *PluginManager) savePluginsState() {
+	if !m.persistState {
+		return
+	}
+	if err := savePluginsState(m.Plugins); err != nil {
+		log.Printf("[WARN] failed to save plugin manager plugins state: %s", err.Error())
+	}
+}
+
+// verifyPluginProcess returns an error unless the process with the given pid is running the plugin executable
+func verifyPluginProcess(pid int, executable string) error {
+	if executable == "" {
+		return fmt.Errorf("the plugin executable was not recorded")
+	}
+	process, err := utils.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if process == nil {
+		return fmt.Errorf("process %d not found", pid)
+	}
+	processExecutable, err := process.Exe()
+	if err != nil {
+		return err
+	}
+	if !sameFile(processExecutable, executable) {
+		return fmt.Errorf("process %d is running %s, not the plugin executable %s", pid, processExecutable, executable)
+	}
+	return nil
+}
+
+// sameFile returns whether two paths refer to the same file, resolving any symlinks
+func sameFile(path1, path2 string) bool {
+	if resolved, err := filepath.EvalSymlinks(path1); err == nil {
+		path1 = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(path2); err == nil {
+		path2 = resolved
+	}
+	return path1 == path2
+}
+
+// killPluginProcess kills a plugin process which we do not have a client for
+func killPluginProcess(pid int) {
+	process, err := utils.FindProcess(pid)
+	if err != nil || process == nil {
+		return
+	}
+	if err := process.Kill(); err != nil {
+		log.Printf("[WARN] failed to kill plugin process %d: %s", pid, err.Error())
+	}
+}
+
+// reapIdlePlugins periodically stops plugin processes which have been idle for longer than the idle timeout
+// a stopped plugin is removed from the Plugins map, so it will be restarted by the next Get
+func (m *PluginManager) reapIdlePlugins() {
+	log.Printf("[TRACE] PluginManager starting idle plugin reaper, idle timeout %s", m.idleTimeout)
+
+	ticker := time.NewTicker(idleCheckInterval(m.idleTimeout))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.shutdownChan:
+			return
+		case <-ticker.C:
+			m.stopIdlePlugins(time.Now())
+		}
+	}
+}
 
-	log.Printf("[TRACE] ************ start plugin %s ********************\n", req.Connection)
+// stopIdlePlugins stops all plugin processes which have not been used since the idle timeout before 'now'
+//
+// once the FDW has the reattach config of a plugin it calls the plugin directly, so Get is not called for each query
+// - instead, a plugin is considered to have been used if its process has used CPU since the previous check
+//
+// the FDW keeps the reattach config for the lifetime of a database session and does not call Get again if the
+// plugin process has gone, so a session which has used a plugin would fail its next query against it.
+// We cannot tell which plugins a session has used, so idle plugins are only stopped when no session is open
+func (m *PluginManager) stopIdlePlugins(now time.Time) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	for connection, p := range m.Plugins {
+		m.updateLastUsed(connection, p, now)
+	}
+	if !m.noOpenSessions() {
+		return
+	}
+	for connection, p := range m.Plugins {
+		if now.Sub(p.lastUsed) > m.idleTimeout {
+			log.Printf("[TRACE] PluginManager stopping plugin for connection '%s' - idle since %s", connection, p.lastUsed)
+			m.killPlugin(connection)
+		}
+	}
+}
+
+// noOpenSessions returns whether there are no open database sessions which may be using a plugin process
+func (m *PluginManager) noOpenSessions() bool {
+	if m.openSessionsFunc == nil {
+		return false
+	}
+	sessions, err := m.openSessionsFunc()
+	if err != nil {
+		log.Printf("[TRACE] PluginManager not stopping idle plugins - failed to get open sessions: %s", err.Error())
+		return false
+	}
+	if sessions > 0 {
+		log.Printf("[TRACE] PluginManager not stopping idle plugins - %d database sessions are open", sessions)
+		return false
+	}
+	return true
+}
+
+// updateLastUsed sets the last used time of the plugin to 'now' if the plugin process has used more than
+// activeCPUThreshold of CPU time since it was last checked
+// NOTE: the caller must hold the mutex
+func (m *PluginManager) updateLastUsed(connection string, p runningPlugin, now time.Time) {
+	cpuTime, err := m.cpuTimeFunc(int(p.reattach.Pid))
+	if err != nil {
+		log.Printf("[TRACE] failed to get CPU time of plugin for connection '%s', pid %d: %s", connection, p.reattach.Pid, err.Error())
+		return
+	}
+	if cpuTime-p.cpuTime > activeCPUThreshold {
+		p.lastUsed = now
+	}
+	p.cpuTime = cpuTime
+	m.Plugins[connection] = p
+}
+
+// activeCPUThreshold is the CPU time a plugin process must use between idle checks to be considered active
+// (an idle plugin process still uses a small amount of CPU, e.g. for garbage collection)
+const activeCPUThreshold = 100 * time.Millisecond
+
+// processCPUTime returns the total (user and system) CPU time used by a process
+func processCPUTime(pid int) (time.Duration, error) {
+	process, err := utils.FindProcess(pid)
+	if err != nil {
+		return 0, err
+	}
+	if process == nil {
+		return 0, fmt.Errorf("process %d not found", pid)
+	}
+	times, err := process.Times()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration((times.User + times.System) * float64(time.Second)), nil
+}
+
+// idleCheckInterval returns the interval at which to check for idle plugins
+// - a quarter of the idle timeout, but at least a second
+func idleCheckInterval(idleTimeout time.Duration) time.Duration {
+	interval := idleTimeout / 4
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+// startPlugin starts a plugin process for the given connection
+// the plugin's stderr output is written to 'stderr' (as well as being logged)
+// it returns the client and the path of the plugin executable
+//
+// NOTE: a separate process is started for every connection, even if several connections use the same plugin.
+// Although ExecuteRequest has a connection field, plugins built with the current plugin SDK only use it for logging:
+// they hold a single connection, which SetConnectionConfig replaces, and Execute always queries that connection.
+// A shared process would therefore run queries for one connection with the config of another.
+// Sharing processes (keying Plugins by plugin rather than connection) requires plugin SDK support for
+// multiple connections per process.
+func (m *PluginManager) startPlugin(connection string, stderr *stderrBuffer) (*plugin.Client, string, error) {
+
+	log.Printf("[TRACE] ************ start plugin %s ********************\n", connection)
 
 	// get connection config
-	connectionConfig, ok := m.connectionConfig[req.Connection]
+	connectionConfig, ok := m.connectionConfig[connection]
 	if !ok {
-		return nil, fmt.Errorf("no config loaded for connection %s", req.Connection)
+		return nil, "", fmt.Errorf("no config loaded for connection %s", connection)
 	}
 
 	pluginPath, err := GetPluginPath(connectionConfig.Plugin, connectionConfig.PluginShortName)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// create the plugin map
@@ -164,20 +589,21 @@ func (m *PluginManager) startPlugin(req *pb.GetRequest) (*plugin.Client, error)
 		pluginName: &sdkshared.WrapperPlugin{},
 	}
 
-	cmd := exec.Command(pluginPath)
-	// pass env to command
-	cmd.Env = os.Environ()
+	// the command applies the connection env and resource limits
+	cmd := pluginCommand(pluginPath, connectionConfig)
 	client := plugin.NewClient(&plugin.ClientConfig{
 		HandshakeConfig:  sdkshared.Handshake,
 		Plugins:          pluginMap,
 		Cmd:              cmd,
 		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		// retain the last lines of stderr, to report if the plugin crashes
+		Stderr: stderr,
 		// pass our logger to the plugin client to ensure plugin logs end up in logfile
 		Logger: m.logger,
 	})
 
 	if _, err := client.Start(); err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return client, nil
+	return client, pluginPath, nil
 }
@@ -70,6 +70,18 @@ func (c *PluginManagerClient) Get(req *pb.GetRequest) (res *pb.GetResponse, err
 	return c.manager.Get(req)
 }
 
+func (c *PluginManagerClient) List(req *pb.ListRequest) (res *pb.ListResponse, err error) {
+	return c.manager.List(req)
+}
+
+func (c *PluginManagerClient) Restart(req *pb.RestartRequest) (res *pb.RestartResponse, err error) {
+	return c.manager.Restart(req)
+}
+
+func (c *PluginManagerClient) Stop(req *pb.StopRequest) (res *pb.StopResponse, err error) {
+	return c.manager.Stop(req)
+}
+
 func (c *PluginManagerClient) Shutdown(req *pb.ShutdownRequest) (res *pb.ShutdownResponse, err error) {
 	log.Printf("[TRACE] PluginManagerClient Shutdown")
 	return c.manager.Shutdown(req)
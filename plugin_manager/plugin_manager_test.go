@@ -0,0 +1,203 @@
+package plugin_manager
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
+)
+
+type stopIdlePluginsTest struct {
+	lastUsed map[string]time.Duration
+	// CPU time used by each plugin since the previous check
+	cpuUsed map[string]time.Duration
+	// the number of open database sessions
+	openSessions int64
+	// if set, getting the open sessions fails
+	openSessionsErr error
+	expected        []string
+}
+
+var testCasesStopIdlePlugins = map[string]stopIdlePluginsTest{
+	"none idle": {
+		lastUsed: map[string]time.Duration{"aws": time.Minute, "gcp": 2 * time.Minute},
+		expected: []string{"aws", "gcp"},
+	},
+	"one idle": {
+		lastUsed: map[string]time.Duration{"aws": time.Minute, "gcp": 20 * time.Minute},
+		expected: []string{"aws"},
+	},
+	"all idle": {
+		lastUsed: map[string]time.Duration{"aws": 11 * time.Minute, "gcp": 20 * time.Minute},
+		expected: []string{},
+	},
+	"idle since requested but active": {
+		lastUsed: map[string]time.Duration{"aws": 11 * time.Minute, "gcp": 20 * time.Minute},
+		cpuUsed:  map[string]time.Duration{"aws": 2 * time.Second},
+		expected: []string{"aws"},
+	},
+	"idle with background cpu usage": {
+		lastUsed: map[string]time.Duration{"aws": 11 * time.Minute, "gcp": 20 * time.Minute},
+		cpuUsed:  map[string]time.Duration{"aws": 10 * time.Millisecond, "gcp": 20 * time.Millisecond},
+		expected: []string{},
+	},
+	"idle with open sessions": {
+		lastUsed:     map[string]time.Duration{"aws": time.Minute, "gcp": 20 * time.Minute},
+		openSessions: 1,
+		expected:     []string{"aws", "gcp"},
+	},
+	"idle with unknown open sessions": {
+		lastUsed:        map[string]time.Duration{"aws": time.Minute, "gcp": 20 * time.Minute},
+		openSessionsErr: fmt.Errorf("service not running"),
+		expected:        []string{"aws", "gcp"},
+	},
+}
+
+func TestStopIdlePlugins(t *testing.T) {
+	now := time.Now()
+	for name, test := range testCasesStopIdlePlugins {
+		m := NewPluginManager(nil, 10*time.Minute, nil)
+		cpuTimes := make(map[int]time.Duration)
+		m.cpuTimeFunc = func(pid int) (time.Duration, error) {
+			return cpuTimes[pid], nil
+		}
+		m.openSessionsFunc = func() (int64, error) {
+			return test.openSessions, test.openSessionsErr
+		}
+		pid := 1000
+		for connection, idleFor := range test.lastUsed {
+			pid++
+			m.Plugins[connection] = runningPlugin{
+				// this client has not been started so Kill is a no-op
+				client:   plugin.NewClient(&plugin.ClientConfig{}),
+				reattach: &pb.ReattachConfig{Pid: int64(pid)},
+				lastUsed: now.Add(-idleFor),
+				cpuTime:  time.Second,
+			}
+			cpuTimes[pid] = time.Second + test.cpuUsed[connection]
+		}
+		m.stopIdlePlugins(now)
+		if len(m.Plugins) != len(test.expected) {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %v, \ngot:\n %v\n", name, test.expected, m.Plugins)
+			continue
+		}
+		for _, connection := range test.expected {
+			if _, ok := m.Plugins[connection]; !ok {
+				t.Errorf("Test: '%s'' FAILED : expected plugin for connection '%s' to still be running", name, connection)
+			}
+		}
+	}
+}
+
+func TestStopIdlePluginsWithoutOpenSessionsFunc(t *testing.T) {
+	now := time.Now()
+	m := NewPluginManager(nil, 10*time.Minute, nil)
+	m.cpuTimeFunc = func(pid int) (time.Duration, error) {
+		return time.Second, nil
+	}
+	m.Plugins["aws"] = runningPlugin{
+		client:   plugin.NewClient(&plugin.ClientConfig{}),
+		reattach: &pb.ReattachConfig{Pid: 1001},
+		lastUsed: now.Add(-20 * time.Minute),
+		cpuTime:  time.Second,
+	}
+	// without a way to check for open sessions, idle plugins are never stopped
+	m.stopIdlePlugins(now)
+	if _, ok := m.Plugins["aws"]; !ok {
+		t.Errorf("Test: 'no open sessions func'' FAILED : expected plugin for connection 'aws' to still be running")
+	}
+}
+
+func TestIdleCheckInterval(t *testing.T) {
+	if interval := idleCheckInterval(time.Minute); interval != 15*time.Second {
+		t.Errorf("Test: 'one minute' FAILED : \nexpected:\n %v, \ngot:\n %v\n", 15*time.Second, interval)
+	}
+	if interval := idleCheckInterval(2 * time.Second); interval != time.Second {
+		t.Errorf("Test: 'two seconds' FAILED : \nexpected:\n %v, \ngot:\n %v\n", time.Second, interval)
+	}
+}
+
+// newTestPluginManager creates a plugin manager whose Plugins map contains an unstarted plugin for each connection
+func newTestPluginManager(connections ...string) *PluginManager {
+	connectionConfig := make(map[string]*pb.ConnectionConfig)
+	m := NewPluginManager(connectionConfig, 0, nil)
+	for i, connection := range connections {
+		connectionConfig[connection] = &pb.ConnectionConfig{
+			Plugin:          "hub.steampipe.io/plugins/test/not_installed@latest",
+			PluginShortName: "not_installed",
+		}
+		m.Plugins[connection] = runningPlugin{
+			// this client has not been started so Kill is a no-op
+			client:     plugin.NewClient(&plugin.ClientConfig{}),
+			reattach:   &pb.ReattachConfig{Pid: int64(1000 + i)},
+			plugin:     connectionConfig[connection].Plugin,
+			configHash: connection + "_hash",
+		}
+	}
+	return m
+}
+
+func TestPluginManagerList(t *testing.T) {
+	m := newTestPluginManager("gcp", "aws")
+	m.crashes["azure"] = &pluginCrashState{failed: true, reason: "plugin crashed"}
+
+	res, err := m.List(&pb.ListRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Plugins) != 2 || res.Plugins[0].Connection != "aws" || res.Plugins[1].Connection != "gcp" {
+		t.Fatalf("expected plugins for aws and gcp, ordered by connection, got %v", res.Plugins)
+	}
+	if p := res.Plugins[0]; p.Pid != 1001 || p.ConfigHash != "aws_hash" || p.Plugin != "hub.steampipe.io/plugins/test/not_installed@latest" {
+		t.Errorf("unexpected plugin status for aws: %v", p)
+	}
+	if reason := res.FailedConnections["azure"]; reason != "plugin crashed" {
+		t.Errorf("expected failed connection azure with reason 'plugin crashed', got %v", res.FailedConnections)
+	}
+}
+
+func TestPluginManagerStop(t *testing.T) {
+	m := newTestPluginManager("aws", "gcp")
+
+	if _, err := m.Stop(&pb.StopRequest{Connection: "aws"}); err != nil {
+		t.Fatalf("unexpected error stopping aws: %v", err)
+	}
+	if _, ok := m.Plugins["aws"]; ok {
+		t.Errorf("expected aws to be removed from the plugins map")
+	}
+	if _, ok := m.Plugins["gcp"]; !ok {
+		t.Errorf("expected gcp to still be running")
+	}
+
+	// stopping a connection with no plugin running is an error
+	if _, err := m.Stop(&pb.StopRequest{Connection: "aws"}); err == nil {
+		t.Errorf("expected error stopping a connection with no plugin running")
+	}
+}
+
+func TestPluginManagerRestart(t *testing.T) {
+	m := newTestPluginManager("aws")
+	m.crashes["aws"] = &pluginCrashState{failed: true, reason: "plugin crashed"}
+
+	// a connection with no config cannot be restarted - and the running plugins are unaffected
+	if _, err := m.Restart(&pb.RestartRequest{Connection: "gcp"}); err == nil {
+		t.Errorf("expected error restarting a connection with no config")
+	}
+	if _, ok := m.Plugins["aws"]; !ok {
+		t.Errorf("expected aws to still be running after failed restart of gcp")
+	}
+
+	// the plugin is not installed, so the restart kills the running plugin but fails to start a new one
+	if _, err := m.Restart(&pb.RestartRequest{Connection: "aws"}); err == nil {
+		t.Errorf("expected error restarting a connection whose plugin is not installed")
+	}
+	if _, ok := m.Plugins["aws"]; ok {
+		t.Errorf("expected aws to be removed from the plugins map")
+	}
+	// the restart clears the crash history
+	if _, ok := m.crashes["aws"]; ok {
+		t.Errorf("expected the crash state of aws to be cleared by the restart")
+	}
+}
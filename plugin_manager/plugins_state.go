@@ -0,0 +1,100 @@
+package plugin_manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/constants"
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
+)
+
+// runningPluginState is the persisted state of a plugin process started by the plugin manager
+// this allows a restarted plugin manager to reattach to the plugin processes started by a previous instance
+type runningPluginState struct {
+	Plugin          string
+	Pid             int
+	Protocol        plugin.Protocol
+	ProtocolVersion int
+	Addr            *pb.SimpleAddr
+	StartTime       time.Time
+	LastUsed        time.Time
+	ConfigHash      string
+	// the path of the plugin executable - used to verify a process is the plugin before killing it
+	Executable string
+}
+
+func newRunningPluginState(p runningPlugin) *runningPluginState {
+	return &runningPluginState{
+		Plugin:          p.plugin,
+		Pid:             int(p.reattach.Pid),
+		Protocol:        plugin.Protocol(p.reattach.Protocol),
+		ProtocolVersion: int(p.reattach.ProtocolVersion),
+		Addr: &pb.SimpleAddr{
+			NetworkString: p.reattach.Addr.Network,
+			AddressString: p.reattach.Addr.Address,
+		},
+		StartTime:  p.startTime,
+		LastUsed:   p.lastUsed,
+		ConfigHash: p.configHash,
+		Executable: p.executable,
+	}
+}
+
+// reattachConfig returns the config to reattach to the plugin process
+// an error is returned if the state has no address (e.g. if the state file has been edited)
+func (s *runningPluginState) reattachConfig() (*plugin.ReattachConfig, error) {
+	if s.Addr == nil {
+		return nil, fmt.Errorf("no address saved for plugin process %d", s.Pid)
+	}
+	return &plugin.ReattachConfig{
+		Protocol:        s.Protocol,
+		ProtocolVersion: s.ProtocolVersion,
+		Addr:            *s.Addr,
+		Pid:             s.Pid,
+	}, nil
+}
+
+// savePluginsState writes the plugins map, keyed by connection, to the plugins state file
+func savePluginsState(plugins map[string]runningPlugin) error {
+	state := make(map[string]*runningPluginState, len(plugins))
+	for connection, p := range plugins {
+		state[connection] = newRunningPluginState(p)
+	}
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(constants.PluginManagerPluginsFilePath(), content, 0644)
+}
+
+// loadPluginsState reads the plugins state file, returning a map of plugin state keyed by connection
+func loadPluginsState() (map[string]*runningPluginState, error) {
+	if !helpers.FileExists(constants.PluginManagerPluginsFilePath()) {
+		log.Printf("[TRACE] plugin manager plugins state file not found")
+		return nil, nil
+	}
+
+	fileContent, err := ioutil.ReadFile(constants.PluginManagerPluginsFilePath())
+	if err != nil {
+		return nil, err
+	}
+	var state map[string]*runningPluginState
+	if err := json.Unmarshal(fileContent, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func deletePluginsState() error {
+	err := os.Remove(constants.PluginManagerPluginsFilePath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
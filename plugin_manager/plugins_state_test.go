@@ -0,0 +1,105 @@
+package plugin_manager
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/constants"
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
+)
+
+func TestPluginsStateRoundTrip(t *testing.T) {
+	installDir, err := ioutil.TempDir("", "plugins_state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(installDir)
+	prevDir := constants.SteampipeDir
+	constants.SteampipeDir = installDir
+	defer func() { constants.SteampipeDir = prevDir }()
+
+	startTime := time.Unix(1600000000, 0).UTC()
+	plugins := map[string]runningPlugin{
+		"aws": {
+			reattach: &pb.ReattachConfig{
+				Protocol:        "grpc",
+				ProtocolVersion: 5,
+				Addr:            &pb.NetAddr{Network: "unix", Address: "/tmp/plugin123"},
+				Pid:             123,
+			},
+			plugin:     "hub.steampipe.io/plugins/turbot/aws@latest",
+			startTime:  startTime,
+			lastUsed:   startTime.Add(time.Minute),
+			configHash: "abcd",
+			executable: "/plugins/aws/steampipe-plugin-aws.plugin",
+		},
+	}
+	if err := savePluginsState(plugins); err != nil {
+		t.Fatalf("Test: 'round trip' FAILED : \nunexpected error saving state %v", err)
+	}
+	state, err := loadPluginsState()
+	if err != nil {
+		t.Fatalf("Test: 'round trip' FAILED : \nunexpected error loading state %v", err)
+	}
+	expected := map[string]*runningPluginState{"aws": newRunningPluginState(plugins["aws"])}
+	if !reflect.DeepEqual(expected, state) {
+		t.Errorf("Test: 'round trip' FAILED : \nexpected:\n %v, \ngot:\n %v\n", expected, state)
+	}
+	if reattach, err := state["aws"].reattachConfig(); err != nil || reattach.Pid != 123 || reattach.Addr.String() != "/tmp/plugin123" {
+		t.Errorf("Test: 'round trip' FAILED : unexpected reattach config %v, error %v", reattach, err)
+	}
+
+	if err := deletePluginsState(); err != nil {
+		t.Fatalf("Test: 'round trip' FAILED : \nunexpected error deleting state %v", err)
+	}
+	if state, err := loadPluginsState(); err != nil || state != nil {
+		t.Errorf("Test: 'round trip' FAILED : expected no state after delete, got %v, %v", state, err)
+	}
+}
+
+func TestPluginsStateReattachConfigNoAddr(t *testing.T) {
+	state := &runningPluginState{Pid: 123}
+	if _, err := state.reattachConfig(); err == nil {
+		t.Errorf("Test: 'no addr' FAILED : expected error for state with no address")
+	}
+}
+
+type verifyPluginProcessTest struct {
+	pid        int
+	executable string
+	expectErr  bool
+}
+
+func TestVerifyPluginProcess(t *testing.T) {
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := map[string]verifyPluginProcessTest{
+		"plugin executable": {
+			pid:        os.Getpid(),
+			executable: executable,
+		},
+		"other executable": {
+			pid:        os.Getpid(),
+			executable: "/plugins/aws/steampipe-plugin-aws.plugin",
+			expectErr:  true,
+		},
+		"executable not recorded": {
+			pid:       os.Getpid(),
+			expectErr: true,
+		},
+	}
+	for name, test := range testCases {
+		err := verifyPluginProcess(test.pid, test.executable)
+		if test.expectErr && err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected error", name)
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("Test: '%s'' FAILED with unexpected error: %v", name, err)
+		}
+	}
+}
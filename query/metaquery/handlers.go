@@ -2,6 +2,7 @@ package metaquery
 
 import (
 	"fmt"
+	"log"
 	"regexp"
 	"sort"
 	"strings"
@@ -14,6 +15,8 @@ import (
 	"github.com/turbot/steampipe/cmdconfig"
 	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/display"
+	"github.com/turbot/steampipe/plugin_manager"
+	pb "github.com/turbot/steampipe/plugin_manager/grpc/proto"
 	"github.com/turbot/steampipe/schema"
 	"github.com/turbot/steampipe/steampipeconfig"
 )
@@ -302,6 +305,8 @@ func listConnections(input *HandlerInput) error {
 	header := []string{"connection", "plugin"}
 	rows := [][]string{}
 
+	failedConnections := getFailedConnections()
+
 	for _, schema := range input.Schema.GetSchemas() {
 		if schema == input.Schema.TemporarySchemaName {
 			continue
@@ -314,6 +319,14 @@ func listConnections(input *HandlerInput) error {
 		}
 	}
 
+	// only show the error column if there are failed connections
+	if len(failedConnections) > 0 {
+		header = append(header, "error")
+		for i, row := range rows {
+			rows[i] = append(row, failedConnections[row[0]])
+		}
+	}
+
 	// sort by connection name
 	sort.SliceStable(rows, func(i, j int) bool {
 		return rows[i][0] < rows[j][0]
@@ -330,6 +343,25 @@ To get information about the columns in a table, run %s
 	return nil
 }
 
+// getFailedConnections returns the connections whose plugin has crashed too often to be restarted,
+// mapped to the failure reason
+// this is only available for the local service - if a connection string is used, nothing is returned
+func getFailedConnections() map[string]string {
+	if viper.GetString(constants.ArgConnectionString) != "" {
+		return nil
+	}
+	pluginManager, err := plugin_manager.GetRunningPluginManager()
+	if err != nil || pluginManager == nil {
+		return nil
+	}
+	resp, err := pluginManager.List(&pb.ListRequest{})
+	if err != nil {
+		log.Printf("[WARN] failed to list plugins: %s", err.Error())
+		return nil
+	}
+	return resp.FailedConnections
+}
+
 func inspectConnection(connectionName string, input *HandlerInput) bool {
 	header := []string{"table", "description"}
 	rows := [][]string{}
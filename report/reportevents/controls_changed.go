@@ -0,0 +1,22 @@
+package reportevents
+
+import (
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// ControlsChanged is raised by the workspace file watcher when controls have been added, deleted or changed
+// a control is changed if its definition, its query, or the value of any variable it references has changed
+type ControlsChanged struct {
+	ChangedControls []*modconfig.Control
+	NewControls     []*modconfig.Control
+	DeletedControls []*modconfig.Control
+}
+
+// IsReportEvent implements ReportEvent interface
+func (*ControlsChanged) IsReportEvent() {}
+
+func (c *ControlsChanged) HasChanges() bool {
+	return len(c.ChangedControls)+
+		len(c.NewControls)+
+		len(c.DeletedControls) > 0
+}
@@ -1,6 +1,10 @@
 package reportevents
 
-import "github.com/turbot/steampipe/report/reportinterfaces"
+import (
+	"encoding/json"
+
+	"github.com/turbot/steampipe/report/reportinterfaces"
+)
 
 type ExecutionComplete struct {
 	Report reportinterfaces.ReportNodeRun
@@ -8,3 +12,19 @@ type ExecutionComplete struct {
 
 // IsReportEvent implements ReportEvent interface
 func (*ExecutionComplete) IsReportEvent() {}
+
+type executionCompletePayload struct {
+	Action     string                         `json:"action"`
+	ReportNode reportinterfaces.ReportNodeRun `json:"report_node"`
+}
+
+// BuildExecutionCompletePayload builds the payload sent to websocket clients when a report execution completes
+// - this is also the json report export format, so an exported report can be loaded by the report UI
+func BuildExecutionCompletePayload(event *ExecutionComplete) []byte {
+	payload := executionCompletePayload{
+		Action:     "execution_complete",
+		ReportNode: event.Report,
+	}
+	jsonString, _ := json.Marshal(payload)
+	return jsonString
+}
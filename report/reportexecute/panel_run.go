@@ -27,6 +27,7 @@ type PanelRun struct {
 
 	runStatus     reportinterfaces.ReportRunStatus
 	executionTree *ReportExecutionTree
+	panel         *modconfig.Panel
 }
 
 func NewPanelRun(panel *modconfig.Panel, executionTree *ReportExecutionTree) *PanelRun {
@@ -38,6 +39,7 @@ func NewPanelRun(panel *modconfig.Panel, executionTree *ReportExecutionTree) *Pa
 		Source:        typehelpers.SafeString(panel.Source),
 		SQL:           typehelpers.SafeString(panel.SQL),
 		executionTree: executionTree,
+		panel:         panel,
 
 		// set to complete, optimistically
 		// if any children have SQL we will set this to ReportRunReady instead
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
 
 	"github.com/stevenle/topsort"
 	"github.com/turbot/steampipe/db/db_common"
@@ -20,17 +21,31 @@ type ReportExecutionTree struct {
 	client          db_common.Client
 	panels          map[string]*PanelRun
 	reports         map[string]*ReportRun
-	workspace       *workspace.Workspace
+	// input runs, keyed by input short name
+	inputs map[string]*ReportInputRun
+	// raw input values, keyed by input short name
+	inputValues map[string]string
+	workspace   *workspace.Workspace
+	// lock to protect the input values, which may be set while the tree is executing
+	inputLock sync.Mutex
+	// lock to ensure the tree is not executed concurrently, e.g. when an input value changes during execution
+	executionLock sync.Mutex
 }
 
 // NewReportExecutionTree creates a result group from a ModTreeItem
-func NewReportExecutionTree(reportName string, client db_common.Client, workspace *workspace.Workspace) (*ReportExecutionTree, error) {
+// inputValues is a map of input short name to the (raw) value selected for that input
+func NewReportExecutionTree(reportName string, client db_common.Client, workspace *workspace.Workspace, inputValues map[string]string) (*ReportExecutionTree, error) {
+	if inputValues == nil {
+		inputValues = make(map[string]string)
+	}
 	// now populate the ReportExecutionTree
 	reportExecutionTree := &ReportExecutionTree{
 		client:          client,
 		dependencyGraph: topsort.NewGraph(),
 		panels:          make(map[string]*PanelRun),
 		reports:         make(map[string]*ReportRun),
+		inputs:          make(map[string]*ReportInputRun),
+		inputValues:     inputValues,
 		workspace:       workspace,
 	}
 
@@ -74,17 +89,29 @@ func (e *ReportExecutionTree) Execute(ctx context.Context) error {
 	log.Println("[TRACE]", "begin ReportExecutionTree.Execute")
 	defer log.Println("[TRACE]", "end ReportExecutionTree.Execute")
 
+	e.executionLock.Lock()
+	defer e.executionLock.Unlock()
+
 	if e.runStatus() == reportinterfaces.ReportRunComplete {
 		// there must be no sql panels to execute
 		log.Println("[TRACE]", "execution tree already complete")
 		return nil
 	}
+
+	// load the options for all inputs
+	for _, input := range e.inputs {
+		if err := input.loadOptions(ctx, e); err != nil {
+			input.Error = err.Error()
+			return err
+		}
+	}
+
 	//get the dependency order
 	executionOrder, err := e.dependencyGraph.TopSort(e.Root.GetName())
 	if err != nil {
 		return err
 	}
-	fmt.Println(executionOrder)
+	log.Println("[TRACE] execution order", executionOrder)
 	for _, name := range executionOrder {
 		err = e.ExecuteNode(ctx, name)
 		if err != nil {
@@ -142,15 +169,9 @@ func (e *ReportExecutionTree) ExecuteNode(ctx context.Context, name string) erro
 		}
 		// if panel has sql execute it
 		if panel.SQL != "" {
-			data, err := e.executePanelSQL(ctx, panel.SQL)
-			if err != nil {
-				// set the error status on the panel - this will raise panel error event
-				panel.SetError(err)
-
+			if err := e.executePanel(ctx, panel); err != nil {
 				return err
 			}
-
-			panel.Data = data
 		}
 		// panel should now be complete, i.e. all it's children should be complete
 		if !panel.ChildrenComplete() {
@@ -165,11 +186,161 @@ func (e *ReportExecutionTree) ExecuteNode(ctx context.Context, name string) erro
 	return fmt.Errorf("invalid block type '%s' passed to ReportExecutionTree.ExecuteNode", name)
 }
 
-func (e *ReportExecutionTree) executePanelSQL(ctx context.Context, query string) ([][]interface{}, error) {
-	queryResult, err := e.client.ExecuteSync(ctx, query, true)
+// SetInputValue sets the value of the given input, validating it against the input type
+func (e *ReportExecutionTree) SetInputValue(inputName, value string) error {
+	input, ok := e.inputs[inputName]
+	if !ok {
+		return fmt.Errorf("input '%s' does not exist in report '%s'", inputName, e.Root.GetName())
+	}
+	if _, err := input.input.ToPostgresValue(value); err != nil {
+		return err
+	}
+
+	e.inputLock.Lock()
+	defer e.inputLock.Unlock()
+	e.inputValues[inputName] = value
+	input.Value = &value
+	return nil
+}
+
+// InputValues returns a copy of the raw input values, keyed by input short name
+func (e *ReportExecutionTree) InputValues() map[string]string {
+	e.inputLock.Lock()
+	defer e.inputLock.Unlock()
+
+	res := make(map[string]string, len(e.inputValues))
+	for k, v := range e.inputValues {
+		res[k] = v
+	}
+	return res
+}
+
+// ExecuteForInput re-executes only the panels whose args reference the given input
+func (e *ReportExecutionTree) ExecuteForInput(ctx context.Context, inputName string) error {
+	log.Printf("[TRACE] ReportExecutionTree.ExecuteForInput %s", inputName)
+
+	// wait for any execution in progress to complete
+	e.executionLock.Lock()
+	defer e.executionLock.Unlock()
+
+	for _, panel := range e.panels {
+		if panel.SQL == "" || panel.panel == nil || !panel.panel.ReferencesInput(inputName) {
+			continue
+		}
+		if err := e.executePanel(ctx, panel); err != nil {
+			return err
+		}
+		panel.SetComplete()
+	}
+	return nil
+}
+
+// addInput creates an input run for the given input and registers it with the tree
+func (e *ReportExecutionTree) addInput(input *modconfig.ReportInput) *ReportInputRun {
+	e.inputLock.Lock()
+	defer e.inputLock.Unlock()
+
+	var value *string
+	if v, ok := e.inputValues[input.ShortName]; ok {
+		value = &v
+	} else if input.Default != nil {
+		value = input.Default
+	}
+	inputRun := NewReportInputRun(input, value)
+	e.inputs[input.ShortName] = inputRun
+	return inputRun
+}
+
+// resolveInputValues returns the postgres representation of the current value of each input which has a value
+func (e *ReportExecutionTree) resolveInputValues() (map[string]string, error) {
+	e.inputLock.Lock()
+	defer e.inputLock.Unlock()
+
+	res := make(map[string]string, len(e.inputs))
+	for name, input := range e.inputs {
+		if input.Value == nil {
+			continue
+		}
+		value, err := input.input.ToPostgresValue(*input.Value)
+		if err != nil {
+			return nil, err
+		}
+		res[name] = value
+	}
+	return res, nil
+}
+
+// executePanel executes the panel sql, setting the panel data
+// if execution fails, the error is set on the panel
+func (e *ReportExecutionTree) executePanel(ctx context.Context, panel *PanelRun) error {
+	data, err := e.executePanelSQL(ctx, panel)
+	if err != nil {
+		// set the error status on the panel - this will raise panel error event
+		panel.SetError(err)
+		return err
+	}
+	panel.Data = data
+	return nil
+}
+
+// executePanelSQL executes the panel sql in a dedicated session, applying the panel cache settings
+// if the panel has args, a prepared statement is created for the panel sql and executed,
+// passing the panel args (with any input references resolved)
+func (e *ReportExecutionTree) executePanelSQL(ctx context.Context, panelRun *PanelRun) ([][]interface{}, error) {
+	session, err := e.client.AcquireSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error acquiring database connection, %s", err.Error())
+	}
+	defer session.Close()
+
+	query := panelRun.SQL
+	panel := panelRun.panel
+	if panel == nil {
+		// there is no panel definition, so no cache settings or args to apply
+		queryResult, err := e.client.ExecuteSyncInSession(ctx, session, query, true)
+		if err != nil {
+			return nil, err
+		}
+		return queryResultToData(queryResult), nil
+	}
+
+	resetCacheSettings, err := db_common.SetSessionCacheSettings(ctx, e.client, session, panel.Cache, panel.CacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	defer resetCacheSettings()
+
+	if panel.HasArgs() {
+		inputValues, err := e.resolveInputValues()
+		if err != nil {
+			return nil, err
+		}
+		args, err := panel.ResolveArgs(inputValues)
+		if err != nil {
+			return nil, err
+		}
+		query, err = modconfig.GetPreparedStatementExecuteSQL(panel, args)
+		if err != nil {
+			return nil, err
+		}
+
+		// the prepared statement only exists for the lifetime of the session
+		preparedStatementName := panel.GetPreparedStatementName()
+		if _, err := e.client.ExecuteSyncInSession(ctx, session, fmt.Sprintf("prepare %s as %s", preparedStatementName, panelRun.SQL), true); err != nil {
+			return nil, fmt.Errorf("failed to create prepared statement for %s: %s", panel.Name(), err.Error())
+		}
+		defer e.client.ExecuteSyncInSession(context.Background(), session, fmt.Sprintf("deallocate %s", preparedStatementName), true)
+	}
+
+	queryResult, err := e.client.ExecuteSyncInSession(ctx, session, query, true)
 	if err != nil {
 		return nil, err
 	}
+	return queryResultToData(queryResult), nil
+}
+
+// queryResultToData converts a query result into an array of rows, the first row containing the column names
+func queryResultToData(queryResult *queryresult.SyncQueryResult) [][]interface{} {
 	var res = make([][]interface{}, len(queryResult.Rows)+1)
 	var columns = make([]interface{}, len(queryResult.ColTypes))
 	for i, c := range queryResult.ColTypes {
@@ -184,5 +355,5 @@ func (e *ReportExecutionTree) executePanelSQL(ctx context.Context, query string)
 		res[i+1] = rowData
 	}
 
-	return res, nil
+	return res
 }
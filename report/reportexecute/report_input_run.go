@@ -0,0 +1,62 @@
+package reportexecute
+
+import (
+	"context"
+	"fmt"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/query/queryresult"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// ReportInputOption is an option of a report input, as returned by the input options query
+type ReportInputOption struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+}
+
+// ReportInputRun is a struct representing the state of a report input for a report run
+type ReportInputRun struct {
+	Name    string               `json:"name"`
+	Title   string               `json:"title,omitempty"`
+	Type    string               `json:"type"`
+	Value   *string              `json:"value,omitempty"`
+	Options []*ReportInputOption `json:"options,omitempty"`
+	Error   string               `json:"error,omitempty"`
+
+	input *modconfig.ReportInput
+}
+
+func NewReportInputRun(input *modconfig.ReportInput, value *string) *ReportInputRun {
+	return &ReportInputRun{
+		Name:  input.ShortName,
+		Title: typehelpers.SafeString(input.Title),
+		Type:  input.GetType(),
+		Value: value,
+		input: input,
+	}
+}
+
+// loadOptions executes the input options query (if any) and populates Options
+func (r *ReportInputRun) loadOptions(ctx context.Context, executionTree *ReportExecutionTree) error {
+	if r.input.SQL == nil {
+		return nil
+	}
+	queryResult, err := executionTree.client.ExecuteSync(ctx, *r.input.SQL, true)
+	if err != nil {
+		return fmt.Errorf("failed to load options for %s: %s", r.input.Name(), err.Error())
+	}
+	if len(queryResult.ColTypes) == 0 {
+		return fmt.Errorf("failed to load options for %s: options query returned no columns", r.input.Name())
+	}
+	r.Options = nil
+	for _, row := range queryResult.Rows {
+		data := row.(*queryresult.RowResult).Data
+		option := &ReportInputOption{Value: typehelpers.ToString(data[0])}
+		if len(data) > 1 {
+			option.Label = typehelpers.ToString(data[1])
+		}
+		r.Options = append(r.Options, option)
+	}
+	return nil
+}
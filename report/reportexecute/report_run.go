@@ -12,6 +12,8 @@ type ReportRun struct {
 	Name  string `json:"name"`
 	Title string `json:"title,omitempty"`
 
+	Inputs []*ReportInputRun `json:"inputs,omitempty"`
+
 	// children
 	PanelRuns  []*PanelRun  `json:"panels,omitempty"`
 	ReportRuns []*ReportRun `json:"reports,omitempty"`
@@ -33,6 +35,13 @@ func NewReportRun(report *modconfig.Report, executionTree *ReportExecutionTree)
 		runStatus: reportinterfaces.ReportRunComplete,
 	}
 
+	// create input runs for all inputs - these are registered with the execution tree
+	// so they can be referenced by the args of any panel in the tree
+	for _, input := range report.Inputs {
+		inputRun := executionTree.addInput(input)
+		r.Inputs = append(r.Inputs, inputRun)
+	}
+
 	// create report runs for all children
 	for _, childReport := range report.Reports {
 		childRun := NewReportRun(childReport, executionTree)
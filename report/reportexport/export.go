@@ -0,0 +1,81 @@
+package reportexport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/report/reportevents"
+	"github.com/turbot/steampipe/report/reportinterfaces"
+)
+
+// report export formats
+const (
+	FormatHTML = "html"
+	FormatJSON = "json"
+)
+
+// ExportTarget is a file to export a report execution to, in a given format
+type ExportTarget struct {
+	Format string
+	File   string
+}
+
+// NewExportTarget parses an export argument - either 'format:file' or a file name whose extension is used to infer the format
+func NewExportTarget(export string) (*ExportTarget, error) {
+	var format, fileName string
+	if parts := strings.SplitN(export, ":", 2); len(parts) == 2 && isValidFormat(parts[0]) {
+		format = parts[0]
+		fileName = parts[1]
+	} else {
+		fileName = export
+		var err error
+		if format, err = inferFormatFromFileName(fileName); err != nil {
+			return nil, err
+		}
+	}
+	fileName, err := helpers.Tildefy(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return &ExportTarget{Format: format, File: fileName}, nil
+}
+
+// Export writes the completed report execution to the target file
+func (t *ExportTarget) Export(root reportinterfaces.ReportNodeRun) error {
+	payload := reportevents.BuildExecutionCompletePayload(&reportevents.ExecutionComplete{Report: root})
+
+	var output []byte
+	var err error
+	switch t.Format {
+	case FormatJSON:
+		// use the same payload as the websocket execution_complete event, so the report UI can reload it
+		output = payload
+	case FormatHTML:
+		output, err = renderHTML(root, payload)
+	default:
+		err = fmt.Errorf("invalid report export format '%s' - must be one of %s", t.Format, []string{FormatHTML, FormatJSON})
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.File, output, 0644)
+}
+
+func isValidFormat(format string) bool {
+	return format == FormatHTML || format == FormatJSON
+}
+
+func inferFormatFromFileName(filename string) (string, error) {
+	switch filepath.Ext(filename) {
+	case ".html", ".htm":
+		return FormatHTML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		// could not infer format
+		return "", fmt.Errorf("could not infer valid report export format from filename '%s'", filename)
+	}
+}
@@ -0,0 +1,107 @@
+package reportexport
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/turbot/steampipe/report/reportexecute"
+)
+
+type newExportTargetTest struct {
+	export   string
+	expected interface{}
+}
+
+var testCasesNewExportTarget = map[string]newExportTargetTest{
+	"html": {
+		export:   "report.html",
+		expected: ExportTarget{Format: FormatHTML, File: "report.html"},
+	},
+	"json": {
+		export:   "out/report.json",
+		expected: ExportTarget{Format: FormatJSON, File: "out/report.json"},
+	},
+	"explicit format": {
+		export:   "json:report.txt",
+		expected: ExportTarget{Format: FormatJSON, File: "report.txt"},
+	},
+	"unknown extension": {
+		export:   "report.txt",
+		expected: "ERROR",
+	},
+}
+
+func TestNewExportTarget(t *testing.T) {
+	for name, test := range testCasesNewExportTarget {
+		target, err := NewExportTarget(test.export)
+		if err != nil {
+			if test.expected != "ERROR" {
+				t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			}
+			continue
+		}
+		if test.expected == "ERROR" {
+			t.Errorf("Test: '%s'' FAILED - expected error", name)
+			continue
+		}
+		// the file name is converted to an absolute path
+		expected := test.expected.(ExportTarget)
+		if target.Format != expected.Format || !strings.HasSuffix(target.File, expected.File) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, *target)
+		}
+	}
+}
+
+var testReportRun = &reportexecute.ReportRun{
+	Name:  "report.test",
+	Title: "Test Report",
+	PanelRuns: []*reportexecute.PanelRun{
+		{
+			Name: "panel.text",
+			Text: "some <text>",
+		},
+		{
+			Name:  "panel.sql",
+			Title: "Buckets",
+			SQL:   "select name from bucket",
+			Data:  [][]interface{}{{"name"}, {[]byte("my-bucket")}, {nil}},
+		},
+	},
+}
+
+func TestExport(t *testing.T) {
+	dir, err := os.MkdirTemp("", "report_export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	jsonTarget := &ExportTarget{Format: FormatJSON, File: filepath.Join(dir, "report.json")}
+	if err := jsonTarget.Export(testReportRun); err != nil {
+		t.Fatal(err)
+	}
+	jsonOutput, _ := os.ReadFile(jsonTarget.File)
+	var payload map[string]interface{}
+	if err := json.Unmarshal(jsonOutput, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload["action"] != "execution_complete" {
+		t.Errorf("expected json action 'execution_complete', got %v", payload["action"])
+	}
+
+	htmlTarget := &ExportTarget{Format: FormatHTML, File: filepath.Join(dir, "report.html")}
+	if err := htmlTarget.Export(testReportRun); err != nil {
+		t.Fatal(err)
+	}
+	htmlBytes, _ := os.ReadFile(htmlTarget.File)
+	html := string(htmlBytes)
+	for _, expected := range []string{"<h1>Test Report</h1>", "some &lt;text&gt;", "<th>name</th>", "<td>my-bucket</td>", `id="report-data"`} {
+		if !strings.Contains(html, expected) {
+			t.Log(html)
+			t.Fatalf("expected html to contain %s", expected)
+		}
+	}
+}
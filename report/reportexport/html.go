@@ -0,0 +1,63 @@
+package reportexport
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+
+	"github.com/turbot/steampipe/report/reportexecute"
+	"github.com/turbot/steampipe/report/reportinterfaces"
+	"github.com/turbot/steampipe/version"
+)
+
+//go:embed html_template/*
+var templateFS embed.FS
+
+type htmlTemplateData struct {
+	Report *reportexecute.ReportRun
+	Panel  *reportexecute.PanelRun
+	// the execution_complete payload, embedded in the page so the report UI can reload it
+	Payload template.JS
+}
+
+var htmlTemplateFuncMap = template.FuncMap{
+	"steampipeversion": func() string { return version.String() },
+	"cell": func(value interface{}) string {
+		switch v := value.(type) {
+		case nil:
+			return ""
+		case []byte:
+			return string(v)
+		default:
+			return fmt.Sprintf("%v", v)
+		}
+	},
+}
+
+// renderHTML renders the execution tree as a self-contained html page
+func renderHTML(root reportinterfaces.ReportNodeRun, payload []byte) ([]byte, error) {
+	t, err := template.
+		New("001.index.tmpl.html").
+		Funcs(htmlTemplateFuncMap).
+		ParseFS(templateFS, "html_template/*")
+	if err != nil {
+		return nil, err
+	}
+
+	data := htmlTemplateData{Payload: template.JS(payload)}
+	switch r := root.(type) {
+	case *reportexecute.ReportRun:
+		data.Report = r
+	case *reportexecute.PanelRun:
+		data.Panel = r
+	default:
+		return nil, fmt.Errorf("cannot render report node '%s' of type %T", root.GetName(), root)
+	}
+
+	var output bytes.Buffer
+	if err := t.Execute(&output, data); err != nil {
+		return nil, err
+	}
+	return output.Bytes(), nil
+}
@@ -4,47 +4,60 @@ import (
 	"context"
 	"log"
 	"net/http"
-	"os"
-	"os/signal"
 	"time"
 
 	"github.com/gin-contrib/static"
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
 	"gopkg.in/olahol/melody.v1"
 )
 
-func StartAPI(ctx context.Context, webSocket *melody.Melody) {
+// StartAPI starts the http server, serving the embedded report UI, the websocket and the REST API
+// it blocks until the context is cancelled, then shuts down the server
+func StartAPI(ctx context.Context, webSocket *melody.Melody, server *Server) error {
+	staticFileSystem, err := newEmbedFileSystem()
+	if err != nil {
+		return err
+	}
+
 	router := gin.Default()
 
-	router.Use(static.Serve("/", static.LocalFile("./static", true)))
+	router.Use(static.Serve("/", staticFileSystem))
 
 	router.GET("/ws", func(c *gin.Context) {
 		webSocket.HandleRequest(c.Writer, c.Request)
 	})
 
+	api := router.Group("/api")
+	api.GET("/reports", server.handleListReports)
+	api.GET("/reports/:name", server.handleGetReportExecution)
+	api.POST("/reports/:name/execute", server.handleExecuteReport)
+
+	listen := ListenType(viper.GetString(constants.ArgReportListen))
 	srv := &http.Server{
-		Addr:    ":5000",
+		Addr:    listen.address(viper.GetInt(constants.ArgReportPort)),
 		Handler: router,
 	}
 
+	listenErrors := make(chan error, 1)
 	go func() {
 		// service connections
-		if err := srv.ListenAndServe(); err != nil {
-			log.Printf("listen: %s\n", err)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			listenErrors <- err
 		}
+		close(listenErrors)
 	}()
 
-	// Wait for interrupt signal to gracefully shutdown the server with
-	// a timeout of 5 seconds.
-	quit := make(chan os.Signal)
-	signal.Notify(quit, os.Interrupt)
-	<-quit
-	log.Println("Shutdown Server ...")
+	// wait for the context to be cancelled (or the server to fail)
+	select {
+	case err := <-listenErrors:
+		return err
+	case <-ctx.Done():
+	}
+	log.Println("[TRACE] shutting down report server")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("Server Shutdown:", err)
-	}
-	log.Println("Server exiting")
+	return srv.Shutdown(shutdownCtx)
 }
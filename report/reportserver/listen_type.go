@@ -0,0 +1,32 @@
+package reportserver
+
+import (
+	"fmt"
+)
+
+// ListenType is a pseudoEnum of network binding for the report server
+type ListenType string
+
+const (
+	// ListenTypeNetwork - bind to all known interfaces
+	ListenTypeNetwork ListenType = "network"
+	// ListenTypeLocal - bind to localhost only
+	ListenTypeLocal ListenType = "local"
+)
+
+// IsValid is a validator for ListenType known values
+func (lt ListenType) IsValid() error {
+	switch lt {
+	case ListenTypeNetwork, ListenTypeLocal:
+		return nil
+	}
+	return fmt.Errorf("Invalid listen type. Can be one of '%v' or '%v'", ListenTypeNetwork, ListenTypeLocal)
+}
+
+// address returns the address the server should listen on for the given port
+func (lt ListenType) address(port int) string {
+	if lt == ListenTypeLocal {
+		return fmt.Sprintf("localhost:%d", port)
+	}
+	return fmt.Sprintf(":%d", port)
+}
@@ -0,0 +1,52 @@
+package reportserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportsResponse struct {
+	Reports map[string]string `json:"reports"`
+}
+
+type ExecuteReportResponse struct {
+	Report string `json:"report"`
+}
+
+// handleListReports returns the full name and title of all reports in the workspace
+func (s *Server) handleListReports(c *gin.Context) {
+	c.JSON(http.StatusOK, ReportsResponse{Reports: getAvailableReports(s.workspace.Mod.Reports)})
+}
+
+// handleGetReportExecution returns the execution tree of the most recent completed execution of a report
+// this is the same payload which is sent to websocket clients when an execution completes
+func (s *Server) handleGetReportExecution(c *gin.Context) {
+	reportName := c.Param("name")
+	if _, ok := s.workspace.Reports[reportName]; !ok {
+		c.JSON(http.StatusNotFound, ErrorPayload{Action: "report_error", Error: fmt.Sprintf("report '%s' does not exist in workspace", reportName)})
+		return
+	}
+	reportNode := s.getLatestExecution(reportName)
+	if reportNode == nil {
+		c.JSON(http.StatusNotFound, ErrorPayload{Action: "report_error", Error: fmt.Sprintf("report '%s' has not been executed", reportName)})
+		return
+	}
+	c.JSON(http.StatusOK, ExecutionPayload{Action: "execution_complete", ReportNode: reportNode})
+}
+
+// handleExecuteReport triggers an execution of a report - execution is asynchronous,
+// the result is sent to interested websocket clients and is available from handleGetReportExecution once complete
+func (s *Server) handleExecuteReport(c *gin.Context) {
+	reportName := c.Param("name")
+	if _, ok := s.workspace.Reports[reportName]; !ok {
+		c.JSON(http.StatusNotFound, ErrorPayload{Action: "report_error", Error: fmt.Sprintf("report '%s' does not exist in workspace", reportName)})
+		return
+	}
+	if err := s.executeReport(reportName); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorPayload{Action: "report_error", Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, ExecuteReportResponse{Report: reportName})
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/executionlayer"
 	"github.com/turbot/steampipe/report/reportevents"
+	"github.com/turbot/steampipe/report/reportexecute"
 	"github.com/turbot/steampipe/report/reportinterfaces"
 	"github.com/turbot/steampipe/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/workspace"
@@ -27,8 +28,13 @@ type Server struct {
 	dbClient      db_common.Client
 	mutex         *sync.Mutex
 	reportClients map[*melody.Session]*ReportClientInfo
-	webSocket     *melody.Melody
-	workspace     *workspace.Workspace
+	// the most recent completed execution of each report, keyed by report name
+	latestExecutions map[string]reportinterfaces.ReportNodeRun
+	// the most recent execution tree of each report, keyed by report name
+	// this is used to re-execute panels when a report input changes
+	executionTrees map[string]*reportexecute.ReportExecutionTree
+	webSocket      *melody.Melody
+	workspace      *workspace.Workspace
 }
 
 type ErrorPayload struct {
@@ -52,8 +58,8 @@ func NewServer(ctx context.Context) (*Server, error) {
 	}
 
 	refreshResult := dbClient.RefreshConnectionAndSearchPaths()
-	if err != nil {
-		return nil, err
+	if refreshResult.Error != nil {
+		return nil, refreshResult.Error
 	}
 	refreshResult.ShowWarnings()
 
@@ -69,12 +75,14 @@ func NewServer(ctx context.Context) (*Server, error) {
 	var mutex = &sync.Mutex{}
 
 	server := &Server{
-		context:       ctx,
-		dbClient:      dbClient,
-		mutex:         mutex,
-		reportClients: reportClients,
-		webSocket:     webSocket,
-		workspace:     loadedWorkspace,
+		context:          ctx,
+		dbClient:         dbClient,
+		mutex:            mutex,
+		reportClients:    reportClients,
+		latestExecutions: make(map[string]reportinterfaces.ReportNodeRun),
+		executionTrees:   make(map[string]*reportexecute.ReportExecutionTree),
+		webSocket:        webSocket,
+		workspace:        loadedWorkspace,
 	}
 
 	loadedWorkspace.RegisterReportEventHandler(server.HandleWorkspaceUpdate)
@@ -83,14 +91,19 @@ func NewServer(ctx context.Context) (*Server, error) {
 	return server, err
 }
 
-func buildAvailableReportsPayload(reports map[string]*modconfig.Report) []byte {
+// getAvailableReports returns a map of report full name to title
+func getAvailableReports(reports map[string]*modconfig.Report) map[string]string {
 	reportsPayload := make(map[string]string)
 	for _, report := range reports {
 		reportsPayload[report.FullName] = types.SafeString(report.Title)
 	}
+	return reportsPayload
+}
+
+func buildAvailableReportsPayload(reports map[string]*modconfig.Report) []byte {
 	payload := AvailableReportsPayload{
 		Action:  "available_reports",
-		Reports: reportsPayload,
+		Reports: getAvailableReports(reports),
 	}
 	jsonString, _ := json.Marshal(payload)
 	return jsonString
@@ -114,19 +127,57 @@ func buildExecutionStartedPayload(event *reportevents.ExecutionStarted) []byte {
 	return jsonString
 }
 
-func buildExecutionCompletePayload(event *reportevents.ExecutionComplete) []byte {
-	payload := ExecutionPayload{
-		Action:     "execution_complete",
-		ReportNode: event.Report,
+// Start starts the API server - this blocks until the server context is cancelled
+func (s *Server) Start() error {
+	go Init(s)
+	return StartAPI(s.context, s.webSocket, s)
+}
+
+// executeReport executes the given report, using the input values of any previous execution
+func (s *Server) executeReport(reportName string) error {
+	s.mutex.Lock()
+	var inputValues map[string]string
+	if previousTree, ok := s.executionTrees[reportName]; ok {
+		inputValues = previousTree.InputValues()
 	}
-	jsonString, _ := json.Marshal(payload)
-	return jsonString
+	s.mutex.Unlock()
+
+	executionTree, err := executionlayer.ExecuteReportNode(s.context, reportName, inputValues, s.workspace, s.dbClient)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.executionTrees[reportName] = executionTree
+	s.mutex.Unlock()
+	return nil
+}
+
+// selectInput sets the value of a report input and re-executes the panels which reference it
+// if the report has not been executed, the whole report is executed with the given input value
+func (s *Server) selectInput(reportName, inputName, value string) error {
+	s.mutex.Lock()
+	executionTree, ok := s.executionTrees[reportName]
+	s.mutex.Unlock()
+
+	if !ok {
+		executionTree, err := executionlayer.ExecuteReportNode(s.context, reportName, map[string]string{inputName: value}, s.workspace, s.dbClient)
+		if err != nil {
+			return err
+		}
+		s.mutex.Lock()
+		s.executionTrees[reportName] = executionTree
+		s.mutex.Unlock()
+		return nil
+	}
+	return executionlayer.ExecuteReportInputChange(s.context, executionTree, inputName, value, s.workspace)
 }
 
-// Starts the API server
-func (s *Server) Start() {
-	go Init(s.context, s.webSocket, s.workspace, s.dbClient, s.reportClients, s.mutex)
-	StartAPI(s.context, s.webSocket)
+// getLatestExecution returns the most recent completed execution of the given report (or nil if there is none)
+func (s *Server) getLatestExecution(reportName string) reportinterfaces.ReportNodeRun {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.latestExecutions[reportName]
 }
 
 func (s *Server) Shutdown() {
@@ -258,7 +309,7 @@ func (s *Server) HandleWorkspaceUpdate(event reportevents.ReportEvent) {
 
 		for _, changedReportName := range changedReportNames {
 			if helpers.StringSliceContains(reportsBeingWatched, changedReportName) {
-				executionlayer.ExecuteReportNode(s.context, changedReportName, s.workspace, s.dbClient)
+				s.executeReport(changedReportName)
 			}
 		}
 
@@ -274,7 +325,7 @@ func (s *Server) HandleWorkspaceUpdate(event reportevents.ReportEvent) {
 
 		for _, newReportName := range newReportNames {
 			if helpers.StringSliceContains(reportsBeingWatched, newReportName) {
-				executionlayer.ExecuteReportNode(s.context, newReportName, s.workspace, s.dbClient)
+				s.executeReport(newReportName)
 			}
 		}
 
@@ -290,9 +341,10 @@ func (s *Server) HandleWorkspaceUpdate(event reportevents.ReportEvent) {
 
 	case *reportevents.ExecutionComplete:
 		fmt.Println("Got execution complete event", *e)
-		payload := buildExecutionCompletePayload(e)
+		payload := reportevents.BuildExecutionCompletePayload(e)
 		reportName := e.Report.GetName()
 		s.mutex.Lock()
+		s.latestExecutions[reportName] = e.Report
 		for session, repoInfo := range s.reportClients {
 			// If this session is interested in this report, broadcast to it
 			if (repoInfo.Report != nil) && *repoInfo.Report == reportName {
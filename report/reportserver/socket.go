@@ -1,24 +1,25 @@
 package reportserver
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
-	"sync"
+	"log"
 
 	"gopkg.in/olahol/melody.v1"
-
-	"github.com/turbot/steampipe/db/db_common"
-	"github.com/turbot/steampipe/executionlayer"
-	"github.com/turbot/steampipe/workspace"
 )
 
 type ClientRequestReportPayload struct {
 	FullName string `json:"full_name"`
 }
 
+type ClientRequestInputPayload struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 type ClientRequestPayload struct {
 	Report ClientRequestReportPayload `json:"report"`
+	Input  ClientRequestInputPayload  `json:"input"`
 }
 
 type ClientRequest struct {
@@ -31,7 +32,12 @@ type AvailableReportsPayload struct {
 	Reports map[string]string `json:"reports"`
 }
 
-func Init(ctx context.Context, webSocket *melody.Melody, workspace *workspace.Workspace, dbClient db_common.Client, socketSessions map[*melody.Session]*ReportClientInfo, mutex *sync.Mutex) {
+func Init(server *Server) {
+	webSocket := server.webSocket
+	workspace := server.workspace
+	socketSessions := server.reportClients
+	mutex := server.mutex
+
 	// Return list of reports on connect
 	webSocket.HandleConnect(func(session *melody.Session) {
 		fmt.Println("Client connected")
@@ -64,8 +70,22 @@ func Init(ctx context.Context, webSocket *melody.Melody, workspace *workspace.Wo
 				reportClientInfo := socketSessions[session]
 				reportClientInfo.Report = &request.Payload.Report.FullName
 				mutex.Unlock()
-				executionlayer.ExecuteReportNode(ctx, request.Payload.Report.FullName, workspace, dbClient)
+				server.executeReport(request.Payload.Report.FullName)
+			case "select_input":
+				log.Printf("[TRACE] select_input: report %s, input %s", request.Payload.Report.FullName, request.Payload.Input.Name)
+				if err := server.selectInput(request.Payload.Report.FullName, request.Payload.Input.Name, request.Payload.Input.Value); err != nil {
+					session.Write(buildInputErrorPayload(err))
+				}
 			}
 		}
 	})
 }
+
+func buildInputErrorPayload(err error) []byte {
+	payload := ErrorPayload{
+		Action: "input_error",
+		Error:  err.Error(),
+	}
+	jsonString, _ := json.Marshal(payload)
+	return jsonString
+}
@@ -0,0 +1,46 @@
+package reportserver
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-contrib/static"
+)
+
+//go:embed static
+var staticAssets embed.FS
+
+// embedFileSystem serves the embedded static assets of the report UI
+// it implements static.ServeFileSystem
+type embedFileSystem struct {
+	http.FileSystem
+	files fs.FS
+}
+
+func newEmbedFileSystem() (static.ServeFileSystem, error) {
+	files, err := fs.Sub(staticAssets, "static")
+	if err != nil {
+		return nil, err
+	}
+	return &embedFileSystem{
+		FileSystem: http.FS(files),
+		files:      files,
+	}, nil
+}
+
+// Exists implements static.ServeFileSystem
+func (e *embedFileSystem) Exists(prefix string, filepath string) bool {
+	p := strings.TrimPrefix(filepath, prefix)
+	if len(p) == len(filepath) {
+		return false
+	}
+	name := strings.TrimPrefix(path.Clean("/"+p), "/")
+	if name == "" {
+		name = "."
+	}
+	_, err := fs.Stat(e.files, name)
+	return err == nil
+}
@@ -0,0 +1,21 @@
+package reportserver
+
+import "testing"
+
+func TestEmbedFileSystemExists(t *testing.T) {
+	fileSystem, err := newEmbedFileSystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := map[string]bool{
+		"/":                true,
+		"/index.html":      true,
+		"/missing.html":    false,
+		"/../static_fs.go": false,
+	}
+	for path, expected := range testCases {
+		if exists := fileSystem.Exists("/", path); exists != expected {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", path, expected, exists)
+		}
+	}
+}
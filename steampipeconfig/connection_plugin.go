@@ -134,12 +134,15 @@ func runPluginManagerInProcess() (*plugin_manager.PluginManager, error) {
 
 	// build config map
 	configMap := make(map[string]*proto.ConnectionConfig)
+	// (this is only used for debugging, so the connection resource limits are not applied)
 	for k, v := range steampipeConfig.Connections {
 		configMap[k] = &proto.ConnectionConfig{
 			Plugin:          v.Plugin,
 			PluginShortName: v.PluginShortName,
 			Config:          v.Config,
+			Env:             v.Env,
 		}
 	}
-	return plugin_manager.NewPluginManager(configMap, logger), nil
+	// the in-process plugin manager is short-lived so does not stop idle plugins
+	return plugin_manager.NewPluginManager(configMap, 0, logger), nil
 }
@@ -108,6 +108,56 @@ func NewConnectionUpdates(schemaNames []string) (*ConnectionUpdates, *RefreshCon
 	return updates, res
 }
 
+// NewDynamicSchemaUpdates returns updates required to bring the schemas of the given connections
+// in line with the schema currently reported by their plugins
+// only connections with a dynamic schema whose schema hash has changed are updated
+func NewDynamicSchemaUpdates(connectionNames []string, schemaNames []string) (*ConnectionUpdates, *RefreshConnectionResult) {
+	utils.LogTime("NewDynamicSchemaUpdates start")
+	defer utils.LogTime("NewDynamicSchemaUpdates end")
+
+	res := &RefreshConnectionResult{}
+	connectionState, err := GetConnectionState(schemaNames)
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+
+	// only consider connections which already exist in the connection state
+	// - any other connections will be added by a full connection refresh
+	connectionsToCheck := make(ConnectionDataMap)
+	for _, name := range connectionNames {
+		if connectionData, ok := connectionState[name]; ok {
+			connectionsToCheck[name] = connectionData
+		}
+	}
+
+	updates := &ConnectionUpdates{
+		Update: ConnectionDataMap{},
+		Delete: ConnectionDataMap{},
+		// the connections which exist are unchanged - only the schema hashes may change
+		RequiredConnectionState: connectionState,
+		currentConnectionState:  connectionState,
+	}
+
+	dynamicSchemaHashMap, connectionsPluginsWithDynamicSchema, res := getSchemaHashesForDynamicSchemas(connectionsToCheck, connectionState)
+	if res.Error != nil {
+		return nil, res
+	}
+
+	for name, requiredHash := range dynamicSchemaHashMap {
+		if connectionData := connectionState[name]; connectionData.SchemaHash != requiredHash {
+			log.Printf("[TRACE] schema of connection %s has changed\n", name)
+			updates.Update[name] = connectionData
+		}
+	}
+
+	// all connections being updated have dynamic schema, so their connection plugins are already loaded
+	updates.ConnectionPlugins = connectionsPluginsWithDynamicSchema
+	updates.updateRequiredStateWithSchemaProperties(dynamicSchemaHashMap)
+
+	return updates, res
+}
+
 // update requiredConnections - set the schema hash and schema mode for all elements of RequiredConnectionState
 // default to the existing state, but if anm update is required, get the updated value
 func (u *ConnectionUpdates) updateRequiredStateWithSchemaProperties(schemaHashMap map[string]string) {
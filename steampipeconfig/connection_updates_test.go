@@ -0,0 +1,90 @@
+package steampipeconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+type newDynamicSchemaUpdatesTest struct {
+	// the connection state file - nil if there is no file
+	state           ConnectionDataMap
+	connectionNames []string
+	schemaNames     []string
+	expectedState   ConnectionDataMap
+}
+
+var staticConnectionData = &ConnectionData{
+	StructVersion: ConnectionDataStructVersion,
+	Plugin:        "hub.steampipe.io/plugins/turbot/connection-test-1@latest",
+	Connection:    &modconfig.Connection{Name: "a"},
+	SchemaMode:    plugin.SchemaModeStatic,
+	SchemaHash:    "abcd",
+}
+
+// these cases do not require a dynamic schema to be fetched from a plugin
+var testCasesNewDynamicSchemaUpdates = map[string]newDynamicSchemaUpdatesTest{
+	"no connection state": {
+		connectionNames: []string{"a"},
+		schemaNames:     []string{"a"},
+		expectedState:   ConnectionDataMap{},
+	},
+	"connection not in connection state": {
+		state:           ConnectionDataMap{"a": staticConnectionData},
+		connectionNames: []string{"b"},
+		schemaNames:     []string{"a", "b"},
+		expectedState:   ConnectionDataMap{"a": staticConnectionData},
+	},
+	"connection schema does not exist": {
+		state:           ConnectionDataMap{"a": staticConnectionData},
+		connectionNames: []string{"a"},
+		schemaNames:     []string{},
+		expectedState:   ConnectionDataMap{},
+	},
+	"static schema": {
+		state:           ConnectionDataMap{"a": staticConnectionData},
+		connectionNames: []string{"a"},
+		schemaNames:     []string{"a"},
+		expectedState:   ConnectionDataMap{"a": staticConnectionData},
+	},
+}
+
+func TestNewDynamicSchemaUpdates(t *testing.T) {
+	installDir, err := ioutil.TempDir("", "dynamic_schema_updates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(installDir)
+	prevDir := constants.SteampipeDir
+	constants.SteampipeDir = installDir
+	defer func() { constants.SteampipeDir = prevDir }()
+
+	for name, test := range testCasesNewDynamicSchemaUpdates {
+		os.Remove(constants.ConnectionStatePath())
+		if test.state != nil {
+			if err := SaveConnectionState(test.state); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		updates, res := NewDynamicSchemaUpdates(test.connectionNames, test.schemaNames)
+		if res.Error != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, res.Error.Error())
+			continue
+		}
+		if len(updates.Update) != 0 || len(updates.Delete) != 0 {
+			t.Errorf("Test: '%s'' FAILED : expected no updates, got update %v, delete %v", name, updates.Update, updates.Delete)
+		}
+		if len(updates.ConnectionPlugins) != 0 {
+			t.Errorf("Test: '%s'' FAILED : expected no connection plugins to be loaded, got %d", name, len(updates.ConnectionPlugins))
+		}
+		if !reflect.DeepEqual(updates.RequiredConnectionState, test.expectedState) {
+			t.Errorf("Test: '%s'' FAILED : expected required state %v, got %v", name, test.expectedState, updates.RequiredConnectionState)
+		}
+	}
+}
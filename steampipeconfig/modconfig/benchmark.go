@@ -29,6 +29,8 @@ type Benchmark struct {
 	Documentation *string            `cty:"documentation" hcl:"documentation" column:"documentation,text"`
 	Tags          *map[string]string `cty:"tags" hcl:"tags" column:"tags,jsonb"`
 	Title         *string            `cty:"title" hcl:"title" column:"title,text"`
+	// the query timeout (in seconds) for controls in the benchmark tree which do not set their own
+	Timeout *int `cty:"timeout" hcl:"timeout" column:"timeout,integer"`
 
 	// list of all block referenced by the resource
 	References []*ResourceReference
@@ -55,7 +57,8 @@ func (b *Benchmark) Equals(other *Benchmark) bool {
 		b.FullName == other.FullName &&
 		typehelpers.SafeString(b.Description) == typehelpers.SafeString(other.Description) &&
 		typehelpers.SafeString(b.Documentation) == typehelpers.SafeString(other.Documentation) &&
-		typehelpers.SafeString(b.Title) == typehelpers.SafeString(other.Title)
+		typehelpers.SafeString(b.Title) == typehelpers.SafeString(other.Title) &&
+		intPtrEqual(b.Timeout, other.Timeout)
 	if !res {
 		return res
 	}
@@ -100,8 +103,14 @@ func (b *Benchmark) GetDeclRange() *hcl.Range {
 // OnDecoded implements HclResource
 func (b *Benchmark) OnDecoded(block *hcl.Block) hcl.Diagnostics {
 	var res hcl.Diagnostics
+	if b.Timeout != nil && *b.Timeout <= 0 {
+		res = append(res, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s has invalid 'timeout' - must be a positive number of seconds", b.FullName),
+			Subject:  &block.DefRange})
+	}
 	if b.ChildNames == nil || len(*b.ChildNames) == 0 {
-		return nil
+		return res
 	}
 
 	// validate each child name appears only once
@@ -198,6 +207,16 @@ func (b *Benchmark) AddChild(child ModTreeItem) error {
 	return fmt.Errorf("benchmark '%s' has no child '%s'", b.Name(), child.Name())
 }
 
+// SetChildren sets the children of a benchmark which was not decoded from hcl
+// (e.g. a benchmark rebuilt from exported check results)
+func (b *Benchmark) SetChildren(children []ModTreeItem) {
+	b.ChildNameStrings = make([]string, len(children))
+	for i, child := range children {
+		b.ChildNameStrings[i] = child.Name()
+	}
+	b.children = children
+}
+
 // AddParent implements ModTreeItem
 func (b *Benchmark) AddParent(parent ModTreeItem) error {
 	b.parents = append(b.parents, parent)
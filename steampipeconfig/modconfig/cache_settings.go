@@ -0,0 +1,15 @@
+package modconfig
+
+// cacheSettingsEqual returns whether two sets of cache settings are equal
+func cacheSettingsEqual(cache *bool, cacheTTL *int, otherCache *bool, otherCacheTTL *int) bool {
+	if (cache == nil) != (otherCache == nil) || (cacheTTL == nil) != (otherCacheTTL == nil) {
+		return false
+	}
+	if cache != nil && *cache != *otherCache {
+		return false
+	}
+	if cacheTTL != nil && *cacheTTL != *otherCacheTTL {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package modconfig
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/utils"
+)
+
+type controlCacheSettingsTest struct {
+	control          *Control
+	expectedCache    *bool
+	expectedCacheTTL *int
+}
+
+var testCasesControlCacheSettings = map[string]controlCacheSettingsTest{
+	"no settings": {
+		control: &Control{},
+	},
+	"control settings": {
+		control:          &Control{Cache: utils.ToBoolPointer(false), CacheTTL: utils.ToIntegerPointer(60)},
+		expectedCache:    utils.ToBoolPointer(false),
+		expectedCacheTTL: utils.ToIntegerPointer(60),
+	},
+	"query settings": {
+		control:          &Control{Query: &Query{Cache: utils.ToBoolPointer(true), CacheTTL: utils.ToIntegerPointer(300)}},
+		expectedCache:    utils.ToBoolPointer(true),
+		expectedCacheTTL: utils.ToIntegerPointer(300),
+	},
+	"control settings override query settings": {
+		control:          &Control{CacheTTL: utils.ToIntegerPointer(60), Query: &Query{Cache: utils.ToBoolPointer(true), CacheTTL: utils.ToIntegerPointer(300)}},
+		expectedCache:    utils.ToBoolPointer(true),
+		expectedCacheTTL: utils.ToIntegerPointer(60),
+	},
+}
+
+func TestControlGetCacheSettings(t *testing.T) {
+	for name, test := range testCasesControlCacheSettings {
+		cache, cacheTTL := test.control.GetCacheSettings()
+		if !cacheSettingsEqual(cache, cacheTTL, test.expectedCache, test.expectedCacheTTL) {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %v %v, \ngot:\n %v %v\n", name, test.expectedCache, test.expectedCacheTTL, cache, cacheTTL)
+		}
+	}
+}
@@ -38,6 +38,10 @@ type Connection struct {
 	Connections map[string]*Connection `json:"-"`
 	// unparsed HCL of plugin specific connection config
 	Config string `json:"Config,omitempty"`
+	// environment variables set for the plugin process, in addition to the steampipe environment
+	Env map[string]string `json:"Env,omitempty"`
+	// resource limits applied to the plugin process
+	Limits *ConnectionLimits `json:"Limits,omitempty"`
 
 	// options
 	Options   *options.Connection `json:"Options,omitempty"`
@@ -58,7 +62,9 @@ func (c *Connection) Equals(other *Connection) bool {
 	}
 	return c.Name == other.Name &&
 		connectionOptionsEqual &&
-		c.Config == other.Config
+		c.Config == other.Config &&
+		reflect.DeepEqual(c.Env, other.Env) &&
+		c.Limits.Equals(other.Limits)
 }
 
 // SetOptions sets the options on the connection
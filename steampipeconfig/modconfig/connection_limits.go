@@ -0,0 +1,65 @@
+package modconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConnectionLimits is a struct representing the resource limits applied to the plugin process for a connection
+// json tags needed as this is stored in the connection state file
+type ConnectionLimits struct {
+	// the maximum virtual memory of the plugin process, in MB
+	MaxMemoryMb *int `json:"MaxMemoryMb,omitempty"`
+	// the nice level of the plugin process (-20 to 19)
+	Nice *int `json:"Nice,omitempty"`
+	// the maximum number of open files for the plugin process
+	MaxOpenFiles *int `json:"MaxOpenFiles,omitempty"`
+}
+
+func (l *ConnectionLimits) Equals(other *ConnectionLimits) bool {
+	if l == nil || other == nil {
+		return l == nil && other == nil
+	}
+	return intPtrEqual(l.MaxMemoryMb, other.MaxMemoryMb) &&
+		intPtrEqual(l.Nice, other.Nice) &&
+		intPtrEqual(l.MaxOpenFiles, other.MaxOpenFiles)
+}
+
+// Validate returns an error for each limit which is out of range
+func (l *ConnectionLimits) Validate() []string {
+	var validationErrors []string
+	if l.MaxMemoryMb != nil && *l.MaxMemoryMb <= 0 {
+		validationErrors = append(validationErrors, fmt.Sprintf("max_memory_mb must be greater than zero, got %d", *l.MaxMemoryMb))
+	}
+	if l.Nice != nil && (*l.Nice < -20 || *l.Nice > 19) {
+		validationErrors = append(validationErrors, fmt.Sprintf("nice must be between -20 and 19, got %d", *l.Nice))
+	}
+	if l.MaxOpenFiles != nil && *l.MaxOpenFiles <= 0 {
+		validationErrors = append(validationErrors, fmt.Sprintf("max_open_files must be greater than zero, got %d", *l.MaxOpenFiles))
+	}
+	return validationErrors
+}
+
+func (l *ConnectionLimits) String() string {
+	if l == nil {
+		return ""
+	}
+	var str []string
+	if l.MaxMemoryMb != nil {
+		str = append(str, fmt.Sprintf("  MaxMemoryMb: %d", *l.MaxMemoryMb))
+	}
+	if l.Nice != nil {
+		str = append(str, fmt.Sprintf("  Nice: %d", *l.Nice))
+	}
+	if l.MaxOpenFiles != nil {
+		str = append(str, fmt.Sprintf("  MaxOpenFiles: %d", *l.MaxOpenFiles))
+	}
+	return strings.Join(str, "\n")
+}
+
+func intPtrEqual(l, r *int) bool {
+	if l == nil || r == nil {
+		return l == nil && r == nil
+	}
+	return *l == *r
+}
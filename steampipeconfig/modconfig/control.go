@@ -23,7 +23,12 @@ type Control struct {
 	SQL              *string            `cty:"sql"  column:"sql,text"`
 	Tags             *map[string]string `cty:"tags"  column:"tags,jsonb"`
 	Title            *string            `cty:"title"  column:"title,text"`
-	Query            *Query
+	// cache settings - if set, these override the connection cache options when the control is executed
+	Cache    *bool `cty:"cache" column:"cache,boolean"`
+	CacheTTL *int  `cty:"cache_ttl" column:"cache_ttl,integer"`
+	// the query timeout (in seconds) - if not set, the timeout of the nearest parent benchmark which sets one is used
+	Timeout *int `cty:"timeout" column:"timeout,integer"`
+	Query   *Query
 	// args
 	// arguments may be specified by either a map of named args or as a list of positional args
 	// we apply special decode logic to convert the params block into a QueryArgs object
@@ -60,7 +65,9 @@ func (c *Control) Equals(other *Control) bool {
 		typehelpers.SafeString(c.SearchPathPrefix) == typehelpers.SafeString(other.SearchPathPrefix) &&
 		typehelpers.SafeString(c.Severity) == typehelpers.SafeString(other.Severity) &&
 		typehelpers.SafeString(c.SQL) == typehelpers.SafeString(other.SQL) &&
-		typehelpers.SafeString(c.Title) == typehelpers.SafeString(other.Title)
+		typehelpers.SafeString(c.Title) == typehelpers.SafeString(other.Title) &&
+		cacheSettingsEqual(c.Cache, c.CacheTTL, other.Cache, other.CacheTTL) &&
+		intPtrEqual(c.Timeout, other.Timeout)
 	if !res {
 		return res
 	}
@@ -239,6 +246,21 @@ func (c *Control) AddReference(ref *ResourceReference) {
 	c.References = append(c.References, ref)
 }
 
+// GetCacheSettings returns the cache settings to use when executing the control
+// settings defined by the control take precedence over those of the query it references
+func (c *Control) GetCacheSettings() (*bool, *int) {
+	cache, cacheTTL := c.Cache, c.CacheTTL
+	if c.Query != nil {
+		if cache == nil {
+			cache = c.Query.Cache
+		}
+		if cacheTTL == nil {
+			cacheTTL = c.Query.CacheTTL
+		}
+	}
+	return cache, cacheTTL
+}
+
 // SetMod implements HclResource
 func (c *Control) SetMod(mod *Mod) {
 	c.Mod = mod
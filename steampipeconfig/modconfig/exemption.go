@@ -0,0 +1,108 @@
+package modconfig
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// the date format accepted for exemption expiry, as an alternative to RFC3339
+const exemptionExpiryDateFormat = "2006-01-02"
+
+// Exemption is a struct representing an exemption block in the mod definition
+// it accepts alarms for resources matching the control and resource patterns, until the optional expiry time
+// patterns are globs, unless enclosed in slashes, e.g. "/^arn:aws:s3:::logs-.*$/", in which case they are regular expressions
+type Exemption struct {
+	Control  string  `hcl:"control" json:"control"`
+	Resource string  `hcl:"resource" json:"resource"`
+	Reason   string  `hcl:"reason" json:"reason"`
+	Expires  *string `hcl:"expires" json:"expires,omitempty"`
+
+	ExpiresAt       *time.Time     `json:"-"`
+	controlPattern  *regexp.Regexp `json:"-"`
+	resourcePattern *regexp.Regexp `json:"-"`
+}
+
+// Initialise compiles the control and resource patterns and parses the expiry time
+func (e *Exemption) Initialise(declRange hcl.Range) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	addError := func(summary string) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  summary,
+			Subject:  &declRange,
+		})
+	}
+
+	if strings.TrimSpace(e.Reason) == "" {
+		addError(fmt.Sprintf("exemption for control '%s' must have a reason", e.Control))
+	}
+
+	var err error
+	if e.controlPattern, err = exemptionPattern(e.Control); err != nil {
+		addError(fmt.Sprintf("invalid exemption control pattern '%s': %s", e.Control, err.Error()))
+	}
+	if e.resourcePattern, err = exemptionPattern(e.Resource); err != nil {
+		addError(fmt.Sprintf("invalid exemption resource pattern '%s': %s", e.Resource, err.Error()))
+	}
+
+	if e.Expires != nil {
+		expiresAt, err := parseExemptionExpiry(*e.Expires)
+		if err != nil {
+			addError(fmt.Sprintf("invalid exemption expiry '%s' - must be a date (YYYY-MM-DD) or an RFC3339 time", *e.Expires))
+		}
+		e.ExpiresAt = expiresAt
+	}
+	return diags
+}
+
+// Expired returns whether the exemption has an expiry time which has passed
+func (e *Exemption) Expired(now time.Time) bool {
+	return e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)
+}
+
+// Matches returns whether the exemption applies to the resource for a control with any of the given names
+// (expired exemptions never match)
+func (e *Exemption) Matches(controlNames []string, resource string, now time.Time) bool {
+	if e.controlPattern == nil || e.resourcePattern == nil || e.Expired(now) {
+		return false
+	}
+	if !e.resourcePattern.MatchString(resource) {
+		return false
+	}
+	for _, name := range controlNames {
+		if e.controlPattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// exemptionPattern converts a glob or a slash-enclosed regular expression into a regular expression
+func exemptionPattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		return regexp.Compile(pattern[1 : len(pattern)-1])
+	}
+	// escape the glob and then convert the wildcards
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return regexp.Compile(fmt.Sprintf("^%s$", expr))
+}
+
+// parseExemptionExpiry parses a date or RFC3339 time
+// a date expires at the end of that day (UTC)
+func parseExemptionExpiry(expires string) (*time.Time, error) {
+	if t, err := time.Parse(exemptionExpiryDateFormat, expires); err == nil {
+		t = t.AddDate(0, 0, 1)
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, expires)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,108 @@
+package modconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/turbot/steampipe/utils"
+)
+
+type exemptionTest struct {
+	exemption Exemption
+	controls  []string
+	resource  string
+	expected  interface{}
+}
+
+var testNow = time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+
+var testCasesExemption = map[string]exemptionTest{
+	"exact match": {
+		exemption: Exemption{Control: "control.c1", Resource: "r1", Reason: "accepted"},
+		controls:  []string{"control.c1"},
+		resource:  "r1",
+		expected:  true,
+	},
+	"qualified control name": {
+		exemption: Exemption{Control: "m1.control.c1", Resource: "r1", Reason: "accepted"},
+		controls:  []string{"control.c1", "m1.control.c1"},
+		resource:  "r1",
+		expected:  true,
+	},
+	"glob": {
+		exemption: Exemption{Control: "control.s3_*", Resource: "arn:aws:s3:::logs-*", Reason: "accepted"},
+		controls:  []string{"control.s3_bucket_versioning"},
+		resource:  "arn:aws:s3:::logs-2021",
+		expected:  true,
+	},
+	"glob does not match other resource": {
+		exemption: Exemption{Control: "control.s3_*", Resource: "arn:aws:s3:::logs-*", Reason: "accepted"},
+		controls:  []string{"control.s3_bucket_versioning"},
+		resource:  "arn:aws:s3:::data",
+		expected:  false,
+	},
+	"glob metacharacters are literal": {
+		exemption: Exemption{Control: "control.c1", Resource: "a.b", Reason: "accepted"},
+		controls:  []string{"control.c1"},
+		resource:  "axb",
+		expected:  false,
+	},
+	"regex": {
+		exemption: Exemption{Control: "/^control\\.c[0-9]$/", Resource: "/^i-[a-f0-9]+$/", Reason: "accepted"},
+		controls:  []string{"control.c2"},
+		resource:  "i-0abc12",
+		expected:  true,
+	},
+	"not expired": {
+		exemption: Exemption{Control: "control.c1", Resource: "r1", Reason: "accepted", Expires: utils.ToStringPointer("2021-11-01")},
+		controls:  []string{"control.c1"},
+		resource:  "r1",
+		expected:  true,
+	},
+	"expired": {
+		exemption: Exemption{Control: "control.c1", Resource: "r1", Reason: "accepted", Expires: utils.ToStringPointer("2021-10-31")},
+		controls:  []string{"control.c1"},
+		resource:  "r1",
+		expected:  false,
+	},
+	"expired rfc3339": {
+		exemption: Exemption{Control: "control.c1", Resource: "r1", Reason: "accepted", Expires: utils.ToStringPointer("2021-11-01T11:00:00Z")},
+		controls:  []string{"control.c1"},
+		resource:  "r1",
+		expected:  false,
+	},
+	"missing reason": {
+		exemption: Exemption{Control: "control.c1", Resource: "r1"},
+		expected:  "ERROR",
+	},
+	"invalid expiry": {
+		exemption: Exemption{Control: "control.c1", Resource: "r1", Reason: "accepted", Expires: utils.ToStringPointer("next week")},
+		expected:  "ERROR",
+	},
+	"invalid regex": {
+		exemption: Exemption{Control: "control.c1", Resource: "/[/", Reason: "accepted"},
+		expected:  "ERROR",
+	},
+}
+
+func TestExemption(t *testing.T) {
+	for name, test := range testCasesExemption {
+		exemption := test.exemption
+		diags := exemption.Initialise(hcl.Range{})
+		if diags.HasErrors() {
+			if test.expected != "ERROR" {
+				t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, diags)
+			}
+			continue
+		}
+		if test.expected == "ERROR" {
+			t.Errorf("Test: '%s'' FAILED : expected error but did not get one", name)
+			continue
+		}
+		if matches := exemption.Matches(test.controls, test.resource, testNow); matches != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, matches)
+		}
+	}
+}
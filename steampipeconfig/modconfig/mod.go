@@ -42,8 +42,9 @@ type Mod struct {
 	References []*ResourceReference
 
 	// blocks
-	Requires  *Requires  `hcl:"requires,block"`
-	OpenGraph *OpenGraph `hcl:"opengraph,block" column:"open_graph,jsonb"`
+	Requires   *Requires    `hcl:"requires,block"`
+	OpenGraph  *OpenGraph   `hcl:"opengraph,block" column:"open_graph,jsonb"`
+	Exemptions []*Exemption `hcl:"exemption,block"`
 
 	Version *goVersion.Version
 
@@ -520,13 +521,18 @@ func (m *Mod) CtyValue() (cty.Value, error) {
 }
 
 // OnDecoded implements HclResource
-func (m *Mod) OnDecoded(*hcl.Block) hcl.Diagnostics {
+func (m *Mod) OnDecoded(block *hcl.Block) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	// initialise our exemptions
+	for _, e := range m.Exemptions {
+		diags = append(diags, e.Initialise(block.DefRange)...)
+	}
 
 	// initialise our Requires
 	if m.Requires == nil {
-		return nil
+		return diags
 	}
-	return m.Requires.Initialise()
+	return append(diags, m.Requires.Initialise()...)
 }
 
 // AddReference implements HclResource
@@ -53,7 +53,18 @@ func (m *ModVersion) Initialise() hcl.Diagnostics {
 	var diags hcl.Diagnostics
 
 	if strings.HasPrefix(m.VersionString, "file:") {
-		m.FilePath = m.VersionString
+		// strip the prefix and expand any leading '~' to the home directory
+		filePath, err := helpers.Tildefy(strings.TrimPrefix(m.VersionString, "file:"))
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("invalid file path '%s' for %s", m.VersionString, m.Name),
+				Detail:   err.Error(),
+				Subject:  &m.DeclRange,
+			})
+			return diags
+		}
+		m.FilePath = filePath
 		return diags
 	}
 	// does the version parse as a semver version
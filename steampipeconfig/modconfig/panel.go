@@ -27,6 +27,18 @@ type Panel struct {
 	Reports []*Report
 	Panels  []*Panel
 
+	// args and param definitions for the panel sql
+	// args may reference report inputs - these args are resolved when the panel is executed
+	Args      *QueryArgs
+	Params    []*ParamDef
+	InputRefs []*InputReference
+
+	PreparedStatementName string
+
+	// cache settings - if set, these override the connection cache options when the panel sql is executed
+	Cache    *bool
+	CacheTTL *int
+
 	DeclRange hcl.Range
 	Mod       *Mod `cty:"mod"`
 
@@ -39,13 +51,14 @@ func NewPanel(block *hcl.Block) *Panel {
 		ShortName: block.Labels[0],
 		FullName:  fmt.Sprintf("panel.%s", block.Labels[0]),
 		DeclRange: block.DefRange,
+		Args:      NewQueryArgs(),
 	}
 	return panel
 }
 
 // PanelFromFile :: factory function
 func PanelFromFile(modPath, filePath string) (MappableResource, []byte, error) {
-	p := &Panel{}
+	p := &Panel{Args: NewQueryArgs()}
 	return p.InitialiseFromFile(modPath, filePath)
 }
 
@@ -224,6 +237,12 @@ func (p *Panel) Diff(new *Panel) *ReportTreeItemDiffs {
 	} else if *p.Height != *new.Height {
 		res.AddPropertyDiff("Height")
 	}
+	if !p.Args.Equals(new.Args) || !p.inputRefsEqual(new) {
+		res.AddPropertyDiff("Args")
+	}
+	if !cacheSettingsEqual(p.Cache, p.CacheTTL, new.Cache, new.CacheTTL) {
+		res.AddPropertyDiff("Cache")
+	}
 
 	res.populateChildDiffs(p, new)
 
@@ -249,3 +268,75 @@ func (p *Panel) containsReport(name string) bool {
 	}
 	return false
 }
+
+// GetParams implements PreparedStatementProvider
+func (p *Panel) GetParams() []*ParamDef {
+	return p.Params
+}
+
+// GetPreparedStatementName implements PreparedStatementProvider
+func (p *Panel) GetPreparedStatementName() string {
+	// lazy load
+	if p.PreparedStatementName == "" {
+		p.PreparedStatementName = preparedStatementName(p)
+	}
+	return p.PreparedStatementName
+}
+
+// ModName implements PreparedStatementProvider
+func (p *Panel) ModName() string {
+	return p.Mod.ShortName
+}
+
+// HasArgs returns whether the panel sql must be executed with args
+func (p *Panel) HasArgs() bool {
+	return !p.Args.Empty() || len(p.Params) > 0 || len(p.InputRefs) > 0
+}
+
+// ReferencesInput returns whether any of the panel args are provided by the given input
+func (p *Panel) ReferencesInput(inputName string) bool {
+	for _, ref := range p.InputRefs {
+		if ref.InputName == inputName {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveArgs returns the panel args, with the values of args which reference inputs
+// populated from the given map of input name to postgres value
+func (p *Panel) ResolveArgs(inputValues map[string]string) (*QueryArgs, error) {
+	args := &QueryArgs{
+		Args:     make(map[string]string, len(p.Args.Args)),
+		ArgsList: make([]string, len(p.Args.ArgsList)),
+	}
+	for k, v := range p.Args.Args {
+		args.Args[k] = v
+	}
+	copy(args.ArgsList, p.Args.ArgsList)
+
+	for _, ref := range p.InputRefs {
+		value, ok := inputValues[ref.InputName]
+		if !ok {
+			return nil, fmt.Errorf("%s references input '%s' which has no value", p.FullName, ref.InputName)
+		}
+		if ref.ArgName != "" {
+			args.Args[ref.ArgName] = value
+		} else {
+			args.ArgsList[ref.ArgIndex] = value
+		}
+	}
+	return args, nil
+}
+
+func (p *Panel) inputRefsEqual(other *Panel) bool {
+	if len(p.InputRefs) != len(other.InputRefs) {
+		return false
+	}
+	for i, ref := range p.InputRefs {
+		if *ref != *other.InputRefs[i] {
+			return false
+		}
+	}
+	return true
+}
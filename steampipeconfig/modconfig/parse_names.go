@@ -15,6 +15,8 @@ const (
 	BlockTypeLocals    = "locals"
 	BlockTypeVariable  = "variable"
 	BlockTypeParam     = "param"
+	BlockTypeRequires  = "requires"
+	BlockTypeInput     = "input"
 )
 
 type ParsedResourceName struct {
@@ -10,6 +10,7 @@ import (
 const maxPreparedStatementNameLength = 63
 const preparesStatementQuerySuffix = "_q"
 const preparesStatementControlSuffix = "_c"
+const preparesStatementPanelSuffix = "_p"
 
 // GetPreparedStatementExecuteSQL return the SQLs to run the query as a prepared statement
 func GetPreparedStatementExecuteSQL(source PreparedStatementProvider, args *QueryArgs) (string, error) {
@@ -34,6 +35,9 @@ func preparedStatementName(source PreparedStatementProvider) string {
 	case *Control:
 		name = t.ShortName
 		suffix = preparesStatementControlSuffix
+	case *Panel:
+		name = t.ShortName
+		suffix = preparesStatementPanelSuffix
 	}
 	// build the hash from the query/control name, mod name and suffix and take the first 4 bytes
 	str := fmt.Sprintf("%s%s%s", prefix, name, suffix)
@@ -26,6 +26,9 @@ type Query struct {
 	SQL              *string            `cty:"sql" hcl:"sql" column:"sql,text"`
 	Tags             *map[string]string `cty:"tags" hcl:"tags" column:"tags,jsonb"`
 	Title            *string            `cty:"title" hcl:"title" column:"title,text"`
+	// cache settings - if set, these override the connection cache options when the query is executed
+	Cache    *bool `cty:"cache" column:"cache,boolean"`
+	CacheTTL *int  `cty:"cache_ttl" column:"cache_ttl,integer"`
 
 	Params []*ParamDef `cty:"params" column:"params,jsonb"`
 	// list of all blocks referenced by the resource
@@ -45,7 +48,8 @@ func (q *Query) Equals(other *Query) bool {
 		typehelpers.SafeString(q.SearchPath) == typehelpers.SafeString(other.SearchPath) &&
 		typehelpers.SafeString(q.SearchPathPrefix) == typehelpers.SafeString(other.SearchPathPrefix) &&
 		typehelpers.SafeString(q.SQL) == typehelpers.SafeString(other.SQL) &&
-		typehelpers.SafeString(q.Title) == typehelpers.SafeString(other.Title)
+		typehelpers.SafeString(q.Title) == typehelpers.SafeString(other.Title) &&
+		cacheSettingsEqual(q.Cache, q.CacheTTL, other.Cache, other.CacheTTL)
 	if !res {
 		return res
 	}
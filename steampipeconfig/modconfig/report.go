@@ -16,6 +16,7 @@ type Report struct {
 
 	Reports []*Report //`hcl:"report,block"`
 	Panels  []*Panel  //`hcl:"panel,block"`
+	Inputs  []*ReportInput
 
 	Mod *Mod `cty:"mod"`
 
@@ -161,6 +162,9 @@ func (r *Report) Diff(new *Report) *ReportTreeItemDiffs {
 	if typehelpers.SafeString(r.Title) != typehelpers.SafeString(new.Title) {
 		res.AddPropertyDiff("Title")
 	}
+	if !r.inputsEqual(new) {
+		res.AddPropertyDiff("Inputs")
+	}
 
 	res.populateChildDiffs(r, new)
 	return res
@@ -185,3 +189,15 @@ func (r *Report) containsReport(name string) bool {
 	}
 	return false
 }
+
+func (r *Report) inputsEqual(other *Report) bool {
+	if len(r.Inputs) != len(other.Inputs) {
+		return false
+	}
+	for i, input := range r.Inputs {
+		if !input.Equals(other.Inputs[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package modconfig
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	typehelpers "github.com/turbot/go-kit/types"
+)
+
+// report input types
+const (
+	ReportInputTypeString = "string"
+	ReportInputTypeNumber = "number"
+	ReportInputTypeBool   = "bool"
+)
+
+// ReportInput is a struct representing an input block of a report
+// an input declares a typed parameter of the report, which panels of the report may pass as args
+// if sql is set, the query results provide the list of options for the input
+type ReportInput struct {
+	ShortName string
+	FullName  string
+
+	Title *string
+	Type  *string
+	// the raw (i.e. not postgres escaped) default value
+	Default *string
+	// query returning the input options - the first column is the value, the optional second column is the label
+	SQL *string
+
+	DeclRange hcl.Range
+}
+
+func NewReportInput(block *hcl.Block) *ReportInput {
+	return &ReportInput{
+		ShortName: block.Labels[0],
+		FullName:  fmt.Sprintf("input.%s", block.Labels[0]),
+		DeclRange: block.DefRange,
+	}
+}
+
+// Name returns the name in format: 'input.<shortName>'
+func (i *ReportInput) Name() string {
+	return i.FullName
+}
+
+// GetType returns the input type, defaulting to string
+func (i *ReportInput) GetType() string {
+	if i.Type == nil {
+		return ReportInputTypeString
+	}
+	return *i.Type
+}
+
+// Validate returns an error if the input type is not supported
+func (i *ReportInput) Validate() error {
+	switch i.GetType() {
+	case ReportInputTypeString, ReportInputTypeNumber, ReportInputTypeBool:
+		return nil
+	}
+	return fmt.Errorf("%s has invalid type '%s' - must be one of %s, %s or %s", i.FullName, i.GetType(), ReportInputTypeString, ReportInputTypeNumber, ReportInputTypeBool)
+}
+
+// decimalNumberRegex matches a decimal number, with optional sign, fraction and exponent
+var decimalNumberRegex = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// ToPostgresValue converts a raw input value into a postgres representation, according to the input type
+func (i *ReportInput) ToPostgresValue(value string) (string, error) {
+	switch i.GetType() {
+	case ReportInputTypeNumber:
+		// only accept plain decimal numbers - ParseFloat also accepts NaN, Inf and hex, which are not valid numeric literals
+		if !decimalNumberRegex.MatchString(value) {
+			return "", fmt.Errorf("invalid value '%s' for %s - must be a number", value, i.FullName)
+		}
+		return value, nil
+	case ReportInputTypeBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value '%s' for %s - must be a bool", value, i.FullName)
+		}
+		return strconv.FormatBool(b), nil
+	default:
+		return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''")), nil
+	}
+}
+
+func (i *ReportInput) Equals(other *ReportInput) bool {
+	return i.FullName == other.FullName &&
+		typehelpers.SafeString(i.Title) == typehelpers.SafeString(other.Title) &&
+		i.GetType() == other.GetType() &&
+		typehelpers.SafeString(i.Default) == typehelpers.SafeString(other.Default) &&
+		typehelpers.SafeString(i.SQL) == typehelpers.SafeString(other.SQL)
+}
+
+// InputReference is an arg of a panel whose value is provided by a report input
+// the arg is identified either by name (for named args) or by index (for positional args)
+type InputReference struct {
+	ArgName   string
+	ArgIndex  int
+	InputName string
+}
@@ -0,0 +1,141 @@
+package modconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe/utils"
+)
+
+type inputToPostgresValueTest struct {
+	inputType *string
+	value     string
+	expected  string
+}
+
+var testCasesInputToPostgresValue = map[string]inputToPostgresValueTest{
+	"default type": {
+		value:    "us-east-1",
+		expected: "'us-east-1'",
+	},
+	"string with quote": {
+		inputType: utils.ToStringPointer(ReportInputTypeString),
+		value:     "o'brien",
+		expected:  "'o''brien'",
+	},
+	"number": {
+		inputType: utils.ToStringPointer(ReportInputTypeNumber),
+		value:     "1.5",
+		expected:  "1.5",
+	},
+	"invalid number": {
+		inputType: utils.ToStringPointer(ReportInputTypeNumber),
+		value:     "1; drop table foo",
+		expected:  "ERROR",
+	},
+	"negative number with exponent": {
+		inputType: utils.ToStringPointer(ReportInputTypeNumber),
+		value:     "-2.5e3",
+		expected:  "-2.5e3",
+	},
+	"nan": {
+		inputType: utils.ToStringPointer(ReportInputTypeNumber),
+		value:     "NaN",
+		expected:  "ERROR",
+	},
+	"infinity": {
+		inputType: utils.ToStringPointer(ReportInputTypeNumber),
+		value:     "-Inf",
+		expected:  "ERROR",
+	},
+	"hex number": {
+		inputType: utils.ToStringPointer(ReportInputTypeNumber),
+		value:     "0x1p-2",
+		expected:  "ERROR",
+	},
+	"bool": {
+		inputType: utils.ToStringPointer(ReportInputTypeBool),
+		value:     "TRUE",
+		expected:  "true",
+	},
+	"invalid bool": {
+		inputType: utils.ToStringPointer(ReportInputTypeBool),
+		value:     "yes",
+		expected:  "ERROR",
+	},
+}
+
+func TestReportInputToPostgresValue(t *testing.T) {
+	for name, test := range testCasesInputToPostgresValue {
+		input := &ReportInput{ShortName: "i", FullName: "input.i", Type: test.inputType}
+		res, err := input.ToPostgresValue(test.value)
+		if err != nil {
+			if test.expected != "ERROR" {
+				t.Errorf("Test: '%s'' FAILED : \nunexpected error %v", name, err)
+			}
+			continue
+		}
+		if test.expected == "ERROR" {
+			t.Errorf("Test: '%s'' FAILED - expected error", name)
+			continue
+		}
+		if test.expected != res {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %v, \ngot:\n %v\n", name, test.expected, res)
+		}
+	}
+}
+
+type panelResolveArgsTest struct {
+	args        *QueryArgs
+	inputRefs   []*InputReference
+	inputValues map[string]string
+	expected    interface{}
+}
+
+var testCasesPanelResolveArgs = map[string]panelResolveArgsTest{
+	"no input refs": {
+		args:     &QueryArgs{Args: map[string]string{"p1": "'val1'"}},
+		expected: &QueryArgs{Args: map[string]string{"p1": "'val1'"}, ArgsList: []string{}},
+	},
+	"named input ref": {
+		args:        &QueryArgs{Args: map[string]string{"p1": "'val1'"}},
+		inputRefs:   []*InputReference{{ArgName: "p2", InputName: "region"}},
+		inputValues: map[string]string{"region": "'us-east-1'"},
+		expected:    &QueryArgs{Args: map[string]string{"p1": "'val1'", "p2": "'us-east-1'"}, ArgsList: []string{}},
+	},
+	"positional input ref": {
+		args:        &QueryArgs{Args: map[string]string{}, ArgsList: []string{"'val1'", ""}},
+		inputRefs:   []*InputReference{{ArgIndex: 1, InputName: "region"}},
+		inputValues: map[string]string{"region": "'us-east-1'"},
+		expected:    &QueryArgs{Args: map[string]string{}, ArgsList: []string{"'val1'", "'us-east-1'"}},
+	},
+	"missing input value": {
+		args:      &QueryArgs{Args: map[string]string{}},
+		inputRefs: []*InputReference{{ArgName: "p1", InputName: "region"}},
+		expected:  "ERROR",
+	},
+}
+
+func TestPanelResolveArgs(t *testing.T) {
+	for name, test := range testCasesPanelResolveArgs {
+		panel := &Panel{FullName: "panel.p", Args: test.args, InputRefs: test.inputRefs}
+		res, err := panel.ResolveArgs(test.inputValues)
+		if err != nil {
+			if test.expected != "ERROR" {
+				t.Errorf("Test: '%s'' FAILED : \nunexpected error %v", name, err)
+			}
+			continue
+		}
+		if test.expected == "ERROR" {
+			t.Errorf("Test: '%s'' FAILED - expected error", name)
+			continue
+		}
+		if !reflect.DeepEqual(test.expected, res) {
+			t.Errorf("Test: '%s'' FAILED : \nexpected:\n %v, \ngot:\n %v\n", name, test.expected, res)
+		}
+		// the panel args must not be modified
+		if len(test.inputRefs) > 0 && reflect.DeepEqual(panel.Args, res) {
+			t.Errorf("Test: '%s'' FAILED : panel args were modified", name)
+		}
+	}
+}
@@ -52,3 +52,24 @@ func (r *Requires) Initialise() hcl.Diagnostics {
 	}
 	return diags
 }
+
+// GetModVersion returns the mod requirement with the given name, or nil if there is none
+func (r *Requires) GetModVersion(name string) *ModVersion {
+	for _, m := range r.Mods {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// AddModVersion adds a mod requirement, replacing any existing requirement for the same mod
+func (r *Requires) AddModVersion(modVersion *ModVersion) {
+	for i, m := range r.Mods {
+		if m.Name == modVersion.Name {
+			r.Mods[i] = modVersion
+			return
+		}
+	}
+	r.Mods = append(r.Mods, modVersion)
+}
@@ -10,6 +10,8 @@ import (
 type Connection struct {
 	Cache    *bool `hcl:"cache" json:"Cache,omitempty"`
 	CacheTTL *int  `hcl:"cache_ttl" json:"CacheTTL,omitempty"`
+	// the interval (in seconds) at which the service re-fetches the schema of connections with a dynamic schema
+	SchemaRefreshInterval *int `hcl:"schema_refresh_interval" json:"SchemaRefreshInterval,omitempty"`
 }
 
 func (c *Connection) ConfigMap() map[string]interface{} {
@@ -28,6 +30,9 @@ func (c *Connection) Merge(otherOptions Options) {
 		if o.CacheTTL != nil {
 			c.CacheTTL = o.CacheTTL
 		}
+		if o.SchemaRefreshInterval != nil {
+			c.SchemaRefreshInterval = o.SchemaRefreshInterval
+		}
 	}
 }
 
@@ -50,5 +55,10 @@ func (c *Connection) String() string {
 	} else {
 		str = append(str, fmt.Sprintf("  CacheTTL: %d", *c.CacheTTL))
 	}
+	if c.SchemaRefreshInterval == nil {
+		str = append(str, "  SchemaRefreshInterval: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  SchemaRefreshInterval: %d", *c.SchemaRefreshInterval))
+	}
 	return strings.Join(str, "\n")
 }
@@ -12,6 +12,16 @@ type Database struct {
 	Port       *int    `hcl:"port"`
 	Listen     *string `hcl:"listen"`
 	SearchPath *string `hcl:"search_path"`
+	// the time (in seconds) a plugin process may be idle before the plugin manager stops it
+	// (idle plugin processes are only stopped when no database session is open)
+	PluginIdleTimeout *int `hcl:"plugin_idle_timeout"`
+	// certificate and key files to use for SSL instead of the generated self-signed certificate
+	SslCert *string `hcl:"ssl_cert"`
+	SslKey  *string `hcl:"ssl_key"`
+	// CA certificate file used to verify client certificates
+	SslCa *string `hcl:"ssl_ca"`
+	// if set, network connections must present a client certificate signed by the ssl_ca
+	SslClientAuth *bool `hcl:"ssl_client_auth"`
 }
 
 // ConfigMap :: create a config map to pass to viper
@@ -28,6 +38,21 @@ func (d *Database) ConfigMap() map[string]interface{} {
 		// convert from string to array
 		res[constants.ArgSearchPath] = searchPathToArray(*d.SearchPath)
 	}
+	if d.PluginIdleTimeout != nil {
+		res[constants.ArgPluginIdleTimeout] = d.PluginIdleTimeout
+	}
+	if d.SslCert != nil {
+		res[constants.ArgSslCert] = d.SslCert
+	}
+	if d.SslKey != nil {
+		res[constants.ArgSslKey] = d.SslKey
+	}
+	if d.SslCa != nil {
+		res[constants.ArgSslCa] = d.SslCa
+	}
+	if d.SslClientAuth != nil {
+		res[constants.ArgSslClientAuth] = d.SslClientAuth
+	}
 	return res
 }
 
@@ -45,6 +70,21 @@ func (d *Database) Merge(otherOptions Options) {
 		if o.SearchPath != nil {
 			d.SearchPath = o.SearchPath
 		}
+		if o.PluginIdleTimeout != nil {
+			d.PluginIdleTimeout = o.PluginIdleTimeout
+		}
+		if o.SslCert != nil {
+			d.SslCert = o.SslCert
+		}
+		if o.SslKey != nil {
+			d.SslKey = o.SslKey
+		}
+		if o.SslCa != nil {
+			d.SslCa = o.SslCa
+		}
+		if o.SslClientAuth != nil {
+			d.SslClientAuth = o.SslClientAuth
+		}
 	}
 }
 
@@ -68,5 +108,30 @@ func (d *Database) String() string {
 	} else {
 		str = append(str, fmt.Sprintf("  SearchPath: %s", *d.SearchPath))
 	}
+	if d.PluginIdleTimeout == nil {
+		str = append(str, "  PluginIdleTimeout: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  PluginIdleTimeout: %d", *d.PluginIdleTimeout))
+	}
+	if d.SslCert == nil {
+		str = append(str, "  SslCert: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  SslCert: %s", *d.SslCert))
+	}
+	if d.SslKey == nil {
+		str = append(str, "  SslKey: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  SslKey: %s", *d.SslKey))
+	}
+	if d.SslCa == nil {
+		str = append(str, "  SslCa: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  SslCa: %s", *d.SslCa))
+	}
+	if d.SslClientAuth == nil {
+		str = append(str, "  SslClientAuth: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  SslClientAuth: %v", *d.SslClientAuth))
+	}
 	return strings.Join(str, "\n")
 }
@@ -9,7 +9,8 @@ import (
 
 // General
 type General struct {
-	UpdateCheck *string `hcl:"update_check"`
+	UpdateCheck       *string `hcl:"update_check"`
+	WorkspaceDatabase *string `hcl:"workspace_database"`
 }
 
 // ConfigMap :: create a config map to pass to viper
@@ -19,6 +20,9 @@ func (g *General) ConfigMap() map[string]interface{} {
 	if g.UpdateCheck != nil {
 		res[constants.ArgUpdateCheck] = g.UpdateCheck
 	}
+	if g.WorkspaceDatabase != nil {
+		res[constants.ArgDatabaseUrl] = g.WorkspaceDatabase
+	}
 
 	return res
 }
@@ -31,6 +35,9 @@ func (g *General) Merge(otherOptions Options) {
 		if o.UpdateCheck != nil {
 			g.UpdateCheck = o.UpdateCheck
 		}
+		if o.WorkspaceDatabase != nil {
+			g.WorkspaceDatabase = o.WorkspaceDatabase
+		}
 	}
 }
 
@@ -44,5 +51,10 @@ func (g *General) String() string {
 	} else {
 		str = append(str, fmt.Sprintf("  UpdateCheck: %s", *g.UpdateCheck))
 	}
+	if g.WorkspaceDatabase == nil {
+		str = append(str, "  WorkspaceDatabase: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  WorkspaceDatabase: %s", *g.WorkspaceDatabase))
+	}
 	return strings.Join(str, "\n")
 }
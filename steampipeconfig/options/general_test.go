@@ -0,0 +1,60 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/utils"
+)
+
+type generalWorkspaceDatabaseTest struct {
+	general  *General
+	other    *General
+	expected string
+}
+
+var testCasesGeneralWorkspaceDatabase = map[string]generalWorkspaceDatabaseTest{
+	"not set": {
+		general:  &General{},
+		other:    &General{},
+		expected: "",
+	},
+	"set": {
+		general:  &General{WorkspaceDatabase: utils.ToStringPointer("postgresql://steampipe@remote:9193/steampipe")},
+		other:    &General{},
+		expected: "postgresql://steampipe@remote:9193/steampipe",
+	},
+	"merged": {
+		general:  &General{},
+		other:    &General{WorkspaceDatabase: utils.ToStringPointer("postgresql://steampipe@remote:9193/steampipe")},
+		expected: "postgresql://steampipe@remote:9193/steampipe",
+	},
+	"merged overrides": {
+		general:  &General{WorkspaceDatabase: utils.ToStringPointer("postgresql://steampipe@local:9193/steampipe")},
+		other:    &General{WorkspaceDatabase: utils.ToStringPointer("postgresql://steampipe@remote:9193/steampipe")},
+		expected: "postgresql://steampipe@remote:9193/steampipe",
+	},
+	"merge does not unset": {
+		general:  &General{WorkspaceDatabase: utils.ToStringPointer("postgresql://steampipe@local:9193/steampipe")},
+		other:    &General{},
+		expected: "postgresql://steampipe@local:9193/steampipe",
+	},
+}
+
+func TestGeneralWorkspaceDatabase(t *testing.T) {
+	for name, test := range testCasesGeneralWorkspaceDatabase {
+		test.general.Merge(test.other)
+		configMap := test.general.ConfigMap()
+		value, ok := configMap[constants.ArgDatabaseUrl]
+		if test.expected == "" {
+			if ok {
+				t.Errorf("Test: '%s'' FAILED : expected %s not to be set, got %v", name, constants.ArgDatabaseUrl, value)
+			}
+			continue
+		}
+		databaseUrl, _ := value.(*string)
+		if databaseUrl == nil || *databaseUrl != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s to be %s, got %v", name, constants.ArgDatabaseUrl, test.expected, value)
+		}
+	}
+}
@@ -46,7 +46,8 @@ func DecodeConnection(block *hcl.Block) (*modconfig.Connection, hcl.Diagnostics)
 		connection.ConnectionNames = connections
 	}
 
-	// check for nested options
+	// check for nested options and process blocks
+	processBlockFound := false
 	for _, connectionBlock := range connectionContent.Blocks {
 		switch connectionBlock.Type {
 		case "options":
@@ -58,11 +59,24 @@ func DecodeConnection(block *hcl.Block) (*modconfig.Connection, hcl.Diagnostics)
 			}
 			connection.SetOptions(opts, connectionBlock)
 
+		case "process":
+			if processBlockFound {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  fmt.Sprintf("connection '%s' has more than one 'process' block", connection.Name),
+					Subject:  &connectionBlock.DefRange,
+				})
+				break
+			}
+			processBlockFound = true
+			moreDiags := decodeConnectionProcess(connection, connectionBlock)
+			diags = append(diags, moreDiags...)
+
 		default:
 			// this can probably never happen
 			diags = append(diags, &hcl.Diagnostic{
 				Severity: hcl.DiagError,
-				Summary:  fmt.Sprintf("invalid block type '%s' - only 'options' blocks are supported for Connections", connectionBlock.Type),
+				Summary:  fmt.Sprintf("invalid block type '%s' - only 'options' and 'process' blocks are supported for Connections", connectionBlock.Type),
 				Subject:  &connectionBlock.DefRange,
 			})
 		}
@@ -78,6 +92,69 @@ func DecodeConnection(block *hcl.Block) (*modconfig.Connection, hcl.Diagnostics)
 	return connection, diags
 }
 
+// decodeConnectionProcess decodes the plugin process environment and resource limits from the 'process' block of a connection
+func decodeConnectionProcess(connection *modconfig.Connection, block *hcl.Block) hcl.Diagnostics {
+	processContent, diags := block.Body.Content(ConnectionProcessBlockSchema)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	if processContent.Attributes["env"] != nil {
+		var env map[string]string
+		moreDiags := gohcl.DecodeExpression(processContent.Attributes["env"].Expr, nil, &env)
+		diags = append(diags, moreDiags...)
+		if !moreDiags.HasErrors() {
+			connection.Env = env
+		}
+	}
+
+	limits, moreDiags := decodeConnectionLimits(connection.Name, block, processContent)
+	diags = append(diags, moreDiags...)
+	if !moreDiags.HasErrors() {
+		connection.Limits = limits
+	}
+	return diags
+}
+
+// decodeConnectionLimits decodes the plugin process resource limits from the process block content
+// if no limits are set, nil is returned
+func decodeConnectionLimits(connectionName string, block *hcl.Block, processContent *hcl.BodyContent) (*modconfig.ConnectionLimits, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	limits := &modconfig.ConnectionLimits{}
+	limitAttributes := map[string]**int{
+		"max_memory_mb":  &limits.MaxMemoryMb,
+		"nice":           &limits.Nice,
+		"max_open_files": &limits.MaxOpenFiles,
+	}
+	limitsSet := false
+	for name, target := range limitAttributes {
+		attr := processContent.Attributes[name]
+		if attr == nil {
+			continue
+		}
+		var value int
+		moreDiags := gohcl.DecodeExpression(attr.Expr, nil, &value)
+		if moreDiags.HasErrors() {
+			diags = append(diags, moreDiags...)
+			continue
+		}
+		*target = &value
+		limitsSet = true
+	}
+	if diags.HasErrors() || !limitsSet {
+		return nil, diags
+	}
+
+	for _, validationError := range limits.Validate() {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("connection '%s' has an invalid limit: %s", connectionName, validationError),
+			Subject:  &block.DefRange,
+		})
+	}
+	return limits, diags
+}
+
 // build a hcl string with all attributes in the conneciton config which are NOT specified in the coneciton block schema
 // this is passed to the plugin who will validate and parse it
 func pluginConnectionConfigToHclString(body hcl.Body, connectionContent *hcl.BodyContent) (string, hcl.Diagnostics) {
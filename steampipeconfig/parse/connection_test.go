@@ -0,0 +1,123 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/utils"
+)
+
+type decodeConnectionTest struct {
+	source         string
+	expectedEnv    map[string]string
+	expectedLimits *modconfig.ConnectionLimits
+	expectedConfig string
+	expectError    bool
+}
+
+var testCasesDecodeConnection = map[string]decodeConnectionTest{
+	"no process block": {
+		source: `connection "aws" {
+  plugin = "aws"
+  regions = ["us-east-1"]
+}`,
+		expectedConfig: "regions = [\"us-east-1\"]\n",
+	},
+	"process block": {
+		source: `connection "aws" {
+  plugin = "aws"
+  regions = ["us-east-1"]
+  process {
+    env = { AWS_PROFILE = "prod" }
+    max_memory_mb = 512
+    nice = 10
+    max_open_files = 1024
+  }
+}`,
+		expectedEnv: map[string]string{"AWS_PROFILE": "prod"},
+		expectedLimits: &modconfig.ConnectionLimits{
+			MaxMemoryMb:  utils.ToIntegerPointer(512),
+			Nice:         utils.ToIntegerPointer(10),
+			MaxOpenFiles: utils.ToIntegerPointer(1024),
+		},
+		expectedConfig: "regions = [\"us-east-1\"]\n",
+	},
+	"process block with env only": {
+		source: `connection "aws" {
+  plugin = "aws"
+  process {
+    env = { AWS_PROFILE = "prod" }
+  }
+}`,
+		expectedEnv: map[string]string{"AWS_PROFILE": "prod"},
+	},
+	"invalid limit": {
+		source: `connection "aws" {
+  plugin = "aws"
+  process {
+    nice = 20
+  }
+}`,
+		expectError: true,
+	},
+	"unknown process attribute": {
+		source: `connection "aws" {
+  plugin = "aws"
+  process {
+    max_cpu = 2
+  }
+}`,
+		expectError: true,
+	},
+	"duplicate process block": {
+		source: `connection "aws" {
+  plugin = "aws"
+  process {
+    nice = 10
+  }
+  process {
+    nice = 5
+  }
+}`,
+		expectError: true,
+	},
+}
+
+func TestDecodeConnection(t *testing.T) {
+	for name, test := range testCasesDecodeConnection {
+		file, diags := hclsyntax.ParseConfig([]byte(test.source), "test.spc", hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			t.Errorf("Test: '%s'' FAILED : failed to parse source: %s", name, diags.Error())
+			continue
+		}
+		content, diags := file.Body.Content(ConfigBlockSchema)
+		if diags.HasErrors() {
+			t.Errorf("Test: '%s'' FAILED : failed to decode source: %s", name, diags.Error())
+			continue
+		}
+
+		connection, diags := DecodeConnection(content.Blocks[0])
+		if test.expectError {
+			if !diags.HasErrors() {
+				t.Errorf("Test: '%s'' FAILED : expected error but did not get one", name)
+			}
+			continue
+		}
+		if diags.HasErrors() {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, diags.Error())
+			continue
+		}
+		if !reflect.DeepEqual(connection.Env, test.expectedEnv) {
+			t.Errorf("Test: '%s'' FAILED : expected env %v, got %v", name, test.expectedEnv, connection.Env)
+		}
+		if !connection.Limits.Equals(test.expectedLimits) {
+			t.Errorf("Test: '%s'' FAILED : expected limits %s, got %s", name, test.expectedLimits, connection.Limits)
+		}
+		if connection.Config != test.expectedConfig {
+			t.Errorf("Test: '%s'' FAILED : expected config %q, got %q", name, test.expectedConfig, connection.Config)
+		}
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/turbot/steampipe/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/steampipeconfig/modconfig/var_config"
 	"github.com/turbot/steampipe/utils"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 )
 
 // A consistent detail message for all "not a valid identifier" diagnostics.
@@ -248,6 +250,7 @@ func decodeQuery(block *hcl.Block, runCtx *RunContext) (*modconfig.Query, *decod
 		valDiags := gohcl.DecodeExpression(attr.Expr, runCtx.EvalCtx, &q.Title)
 		diags = append(diags, valDiags...)
 	}
+	diags = append(diags, decodeCacheSettings(content, runCtx, q.FullName, &q.Cache, &q.CacheTTL)...)
 	for _, block := range content.Blocks {
 		if block.Type == modconfig.BlockTypeParam {
 			param, moreDiags := decodeParam(block, runCtx, q.FullName)
@@ -369,6 +372,8 @@ func decodeControl(block *hcl.Block, runCtx *RunContext) (*modconfig.Control, *d
 		valDiags := gohcl.DecodeExpression(attr.Expr, runCtx.EvalCtx, &c.Title)
 		diags = append(diags, valDiags...)
 	}
+	diags = append(diags, decodeCacheSettings(content, runCtx, c.FullName, &c.Cache, &c.CacheTTL)...)
+	diags = append(diags, decodeTimeout(content, runCtx, c.FullName, &c.Timeout)...)
 	if attr, exists := content.Attributes["args"]; exists {
 		if params, diags := decodeControlArgs(attr, runCtx.EvalCtx, c.FullName); !diags.HasErrors() {
 			c.Args = params
@@ -479,12 +484,114 @@ func decodePanel(block *hcl.Block, runCtx *RunContext) (*modconfig.Panel, *decod
 	diags = decodeProperty(content, "sql", &panel.SQL, runCtx)
 	res.handleDecodeDiags(diags)
 
+	diags = decodeCacheSettings(content, runCtx, panel.FullName, &panel.Cache, &panel.CacheTTL)
+	res.handleDecodeDiags(diags)
+
+	if attr, exists := content.Attributes["args"]; exists {
+		args, inputRefs, diags := decodePanelArgs(attr, runCtx.EvalCtx, panel.FullName)
+		if !diags.HasErrors() {
+			panel.Args = args
+			panel.InputRefs = inputRefs
+		}
+		res.handleDecodeDiags(diags)
+	}
+
+	for _, block := range content.Blocks {
+		if block.Type == modconfig.BlockTypeParam {
+			paramDef, diags := decodeParam(block, runCtx, panel.FullName)
+			if !diags.HasErrors() {
+				panel.Params = append(panel.Params, paramDef)
+			}
+			res.handleDecodeDiags(diags)
+		}
+	}
+
 	diags = decodeReportBlocks(panel, content, runCtx)
 	res.handleDecodeDiags(diags)
 
 	return panel, res
 }
 
+// decodePanelArgs decodes the args of a panel - this is either a map or an array
+// arg values which reference a report input (e.g. input.region) are not evaluated,
+// instead an InputReference is returned for them, and the value is resolved when the panel is executed
+func decodePanelArgs(attr *hcl.Attribute, evalCtx *hcl.EvalContext, panelName string) (*modconfig.QueryArgs, []*modconfig.InputReference, hcl.Diagnostics) {
+	var args = modconfig.NewQueryArgs()
+	var inputRefs []*modconfig.InputReference
+	var diags hcl.Diagnostics
+
+	switch expr := attr.Expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range expr.Items {
+			argName := hcl.ExprAsKeyword(item.KeyExpr)
+			if argName == "" {
+				keyVal, moreDiags := item.KeyExpr.Value(evalCtx)
+				diags = append(diags, moreDiags...)
+				if moreDiags.HasErrors() || keyVal.Type() != cty.String {
+					continue
+				}
+				argName = keyVal.AsString()
+			}
+			if inputName, ok := inputReferenceName(item.ValueExpr); ok {
+				inputRefs = append(inputRefs, &modconfig.InputReference{ArgName: argName, InputName: inputName})
+				continue
+			}
+			valStr, moreDiags := decodePanelArgValue(item.ValueExpr, evalCtx, panelName)
+			diags = append(diags, moreDiags...)
+			args.Args[argName] = valStr
+		}
+	case *hclsyntax.TupleConsExpr:
+		args.ArgsList = make([]string, len(expr.Exprs))
+		for i, valueExpr := range expr.Exprs {
+			if inputName, ok := inputReferenceName(valueExpr); ok {
+				inputRefs = append(inputRefs, &modconfig.InputReference{ArgIndex: i, InputName: inputName})
+				continue
+			}
+			valStr, moreDiags := decodePanelArgValue(valueExpr, evalCtx, panelName)
+			diags = append(diags, moreDiags...)
+			args.ArgsList[i] = valStr
+		}
+	default:
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s has invalid args", panelName),
+			Detail:   "'args' property must be either a map or an array",
+			Subject:  &attr.Range,
+		})
+	}
+	return args, inputRefs, diags
+}
+
+func decodePanelArgValue(expr hcl.Expression, evalCtx *hcl.EvalContext, panelName string) (string, hcl.Diagnostics) {
+	v, diags := expr.Value(evalCtx)
+	if diags.HasErrors() {
+		return "", diags
+	}
+	valStr, err := ctyToPostgresString(v)
+	if err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s has invalid args", panelName),
+			Detail:   err.Error(),
+			Subject:  expr.Range().Ptr(),
+		})
+	}
+	return valStr, diags
+}
+
+// if the expression is a reference to a report input (input.<name>), return the input name
+func inputReferenceName(expr hcl.Expression) (string, bool) {
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() || len(traversal) != 2 || traversal.RootName() != modconfig.BlockTypeInput {
+		return "", false
+	}
+	attr, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+	return attr.Name, true
+}
+
 func decodeReport(block *hcl.Block, runCtx *RunContext) (*modconfig.Report, *decodeResult) {
 	res := &decodeResult{}
 
@@ -495,12 +602,58 @@ func decodeReport(block *hcl.Block, runCtx *RunContext) (*modconfig.Report, *dec
 	diags = decodeProperty(content, "title", &report.Title, runCtx)
 	res.handleDecodeDiags(diags)
 
+	for _, block := range content.Blocks {
+		if block.Type == modconfig.BlockTypeInput {
+			input, diags := decodeReportInput(block, runCtx)
+			if !diags.HasErrors() {
+				report.Inputs = append(report.Inputs, input)
+			}
+			res.handleDecodeDiags(diags)
+		}
+	}
+
 	diags = decodeReportBlocks(report, content, runCtx)
 	res.handleDecodeDiags(diags)
 
 	return report, res
 }
 
+func decodeReportInput(block *hcl.Block, runCtx *RunContext) (*modconfig.ReportInput, hcl.Diagnostics) {
+	input := modconfig.NewReportInput(block)
+
+	content, diags := block.Body.Content(ReportInputBlockSchema)
+
+	diags = append(diags, decodeProperty(content, "title", &input.Title, runCtx)...)
+	diags = append(diags, decodeProperty(content, "type", &input.Type, runCtx)...)
+	diags = append(diags, decodeProperty(content, "sql", &input.SQL, runCtx)...)
+	if attr, exists := content.Attributes["default"]; exists {
+		v, moreDiags := attr.Expr.Value(runCtx.EvalCtx)
+		diags = append(diags, moreDiags...)
+		if !moreDiags.HasErrors() {
+			// store the raw value - it is converted to a postgres value according to the input type when used
+			if strVal, err := convert.Convert(v, cty.String); err == nil && !strVal.IsNull() {
+				defaultValue := strVal.AsString()
+				input.Default = &defaultValue
+			} else {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  fmt.Sprintf("%s has invalid default", input.FullName),
+					Detail:   "default must be a string, number or bool",
+					Subject:  &attr.Range,
+				})
+			}
+		}
+	}
+	if err := input.Validate(); err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  err.Error(),
+			Subject:  &block.DefRange,
+		})
+	}
+	return input, diags
+}
+
 func decodeReportBlocks(resource modconfig.ModTreeItem, content *hcl.BodyContent, runCtx *RunContext) hcl.Diagnostics {
 	var diags hcl.Diagnostics
 	for _, b := range content.Blocks {
@@ -511,6 +664,9 @@ func decodeReportBlocks(resource modconfig.ModTreeItem, content *hcl.BodyContent
 			childResource, decodeResult = decodePanel(b, runCtx)
 		case modconfig.BlockTypeReport:
 			childResource, decodeResult = decodeReport(b, runCtx)
+		default:
+			// inputs and params are decoded by the parent
+			continue
 		}
 
 		// add this panel to the mod
@@ -525,6 +681,38 @@ func decodeReportBlocks(resource modconfig.ModTreeItem, content *hcl.BodyContent
 	return diags
 }
 
+// decodeCacheSettings decodes the 'cache' and 'cache_ttl' properties of a query, control or panel
+func decodeCacheSettings(content *hcl.BodyContent, runCtx *RunContext, resourceName string, cache **bool, cacheTTL **int) hcl.Diagnostics {
+	diags := decodeProperty(content, "cache", cache, runCtx)
+	if attr, exists := content.Attributes["cache_ttl"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, runCtx.EvalCtx, cacheTTL)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() && *cacheTTL != nil && **cacheTTL <= 0 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("%s has invalid 'cache_ttl' - must be a positive number of seconds", resourceName),
+				Subject:  &attr.Range,
+			})
+		}
+	}
+	return diags
+}
+
+func decodeTimeout(content *hcl.BodyContent, runCtx *RunContext, resourceName string, timeout **int) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if attr, exists := content.Attributes["timeout"]; exists {
+		diags = gohcl.DecodeExpression(attr.Expr, runCtx.EvalCtx, timeout)
+		if !diags.HasErrors() && *timeout != nil && **timeout <= 0 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("%s has invalid 'timeout' - must be a positive number of seconds", resourceName),
+				Subject:  &attr.Range,
+			})
+		}
+	}
+	return diags
+}
+
 func decodeProperty(content *hcl.BodyContent, property string, dest interface{}, runCtx *RunContext) hcl.Diagnostics {
 	var diags hcl.Diagnostics
 	if title, ok := content.Attributes[property]; ok {
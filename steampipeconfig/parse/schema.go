@@ -37,6 +37,28 @@ var ConnectionBlockSchema = &hcl.BodySchema{
 			Type:       "options",
 			LabelNames: []string{"type"},
 		},
+		{
+			Type: "process",
+		},
+	},
+}
+
+// ConnectionProcessBlockSchema is the schema for the 'process' block of a connection,
+// which configures the environment and resource limits of the plugin process
+var ConnectionProcessBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "env",
+		},
+		{
+			Name: "max_memory_mb",
+		},
+		{
+			Name: "nice",
+		},
+		{
+			Name: "max_open_files",
+		},
 	},
 }
 
@@ -87,6 +109,9 @@ var PanelBlockSchema = &hcl.BodySchema{
 		{Name: "height"},
 		{Name: "source"},
 		{Name: "sql"},
+		{Name: "args"},
+		{Name: "cache"},
+		{Name: "cache_ttl"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
@@ -97,6 +122,10 @@ var PanelBlockSchema = &hcl.BodySchema{
 			Type:       "report",
 			LabelNames: []string{"type"},
 		},
+		{
+			Type:       "param",
+			LabelNames: []string{"name"},
+		},
 	},
 }
 
@@ -113,6 +142,19 @@ var ReportBlockSchema = &hcl.BodySchema{
 			Type:       "report",
 			LabelNames: []string{"type"},
 		},
+		{
+			Type:       "input",
+			LabelNames: []string{"name"},
+		},
+	},
+}
+
+var ReportInputBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "title"},
+		{Name: "type"},
+		{Name: "default"},
+		{Name: "sql"},
 	},
 }
 
@@ -128,6 +170,9 @@ var ControlBlockSchema = &hcl.BodySchema{
 		{Name: "tags"},
 		{Name: "title"},
 		{Name: "args"},
+		{Name: "cache"},
+		{Name: "cache_ttl"},
+		{Name: "timeout"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
@@ -146,6 +191,8 @@ var QueryBlockSchema = &hcl.BodySchema{
 		{Name: "sql"},
 		{Name: "tags"},
 		{Name: "title"},
+		{Name: "cache"},
+		{Name: "cache_ttl"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
@@ -185,8 +185,9 @@ func (c *SteampipeConfig) GetConnectionOptions(connectionName string) *options.C
 
 	// create a copy of the options to return
 	result := &options.Connection{
-		Cache:    c.DefaultConnectionOptions.Cache,
-		CacheTTL: c.DefaultConnectionOptions.CacheTTL,
+		Cache:                 c.DefaultConnectionOptions.Cache,
+		CacheTTL:              c.DefaultConnectionOptions.CacheTTL,
+		SchemaRefreshInterval: c.DefaultConnectionOptions.SchemaRefreshInterval,
 	}
 	if connection.Options.Cache != nil {
 		log.Printf("[TRACE] connection defines cache option %v", *connection.Options.Cache)
@@ -195,6 +196,9 @@ func (c *SteampipeConfig) GetConnectionOptions(connectionName string) *options.C
 	if connection.Options.CacheTTL != nil {
 		result.CacheTTL = connection.Options.CacheTTL
 	}
+	if connection.Options.SchemaRefreshInterval != nil {
+		result.SchemaRefreshInterval = connection.Options.SchemaRefreshInterval
+	}
 
 	return result
 }
@@ -4,3 +4,13 @@ package utils
 func ToStringPointer(s string) *string {
 	return &s
 }
+
+// ToIntegerPointer converts an integer into its pointer
+func ToIntegerPointer(i int) *int {
+	return &i
+}
+
+// ToBoolPointer converts a boolean into its pointer
+func ToBoolPointer(b bool) *bool {
+	return &b
+}
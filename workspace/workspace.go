@@ -64,6 +64,11 @@ func Load(workspacePath string) (*Workspace, error) {
 		return nil, err
 	}
 
+	// verify the installed mod dependencies match the workspace lock file
+	if err := workspace.verifyWorkspaceLock(); err != nil {
+		return nil, err
+	}
+
 	// load the workspace mod
 	if err := workspace.loadWorkspaceMod(); err != nil {
 		return nil, err
@@ -471,6 +476,18 @@ func (w *Workspace) getPanelMap() map[string]*modconfig.Panel {
 	return panels
 }
 
+// return a map of all unique controls, keyed by name
+// not we cannot just use Controls as this contains duplicates (qualified and unqualified version)
+func (w *Workspace) getControlMap() map[string]*modconfig.Control {
+	controls := make(map[string]*modconfig.Control, len(w.Controls))
+	for _, c := range w.Controls {
+		// refetch the name property to avoid duplicates
+		// (as we save resources with qualified and unqualified name)
+		controls[c.Name()] = c
+	}
+	return controls
+}
+
 // return a map of all unique reports, keyed by name
 // not we cannot just use Reports as this contains duplicates (qualified and unqualified version)
 func (w *Workspace) getReportMap() map[string]*modconfig.Report {
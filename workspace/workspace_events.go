@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"context"
+	"strings"
 
 	"github.com/turbot/steampipe/db/db_common"
 
@@ -28,6 +29,8 @@ func (w *Workspace) handleFileWatcherEvent(client db_common.Client, events []fsn
 	// store prev resources so we can detect diffs
 	prevPanels := w.getPanelMap()
 	prevReports := w.getReportMap()
+	prevControls := w.getControlMap()
+	prevVariables := w.Variables
 	prevResourceMaps := w.GetResourceMaps()
 
 	// now reload the workspace
@@ -52,6 +55,7 @@ func (w *Workspace) handleFileWatcherEvent(client db_common.Client, events []fsn
 		client.RefreshSessions(context.Background())
 	}
 	w.raiseReportChangedEvents(w.getPanelMap(), prevPanels, w.getReportMap(), prevReports)
+	w.raiseControlsChangedEvent(w.getControlMap(), prevControls, w.Variables, prevVariables)
 }
 
 func (w *Workspace) raiseReportChangedEvents(panels, prevPanels map[string]*modconfig.Panel, reports, prevReports map[string]*modconfig.Report) {
@@ -93,3 +97,53 @@ func (w *Workspace) raiseReportChangedEvents(panels, prevPanels map[string]*modc
 		w.PublishReportEvent(event)
 	}
 }
+
+func (w *Workspace) raiseControlsChangedEvent(controls, prevControls map[string]*modconfig.Control, variables, prevVariables map[string]*modconfig.Variable) {
+	event := &reportevents.ControlsChanged{}
+
+	// build a map of the names of variables whose values have changed
+	changedVariables := make(map[string]bool)
+	for name, variable := range variables {
+		if prevVariable, ok := prevVariables[name]; !ok || !prevVariable.Equals(variable) {
+			changedVariables[variable.Name()] = true
+		}
+	}
+
+	// detect changed and deleted controls
+	for name, prevControl := range prevControls {
+		if currentControl, ok := controls[name]; ok {
+			if !prevControl.Equals(currentControl) || controlReferencesAny(currentControl, changedVariables) {
+				event.ChangedControls = append(event.ChangedControls, currentControl)
+			}
+		} else {
+			event.DeletedControls = append(event.DeletedControls, prevControl)
+		}
+	}
+	// now detect new controls
+	for name, c := range controls {
+		if _, ok := prevControls[name]; !ok {
+			event.NewControls = append(event.NewControls, c)
+		}
+	}
+	if event.HasChanges() {
+		w.PublishReportEvent(event)
+	}
+}
+
+// controlReferencesAny returns whether the control, or its query, references any of the named resources
+func controlReferencesAny(control *modconfig.Control, names map[string]bool) bool {
+	references := control.References
+	if control.Query != nil {
+		references = append(append([]*modconfig.ResourceReference{}, references...), control.Query.References...)
+	}
+	for _, ref := range references {
+		// a reference may be to a property of the resource, e.g. 'var.v1.region'
+		parts := strings.Split(ref.To, ".")
+		for i := range parts {
+			if names[strings.Join(parts[:i+1], ".")] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package workspace
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/turbot/steampipe/report/reportevents"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/utils"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestControlsChangedEvent(t *testing.T) {
+	prevVariables := map[string]*modconfig.Variable{
+		"var.region": {ShortName: "region", FullName: "var.region", Value: cty.StringVal("us-east-1")},
+		"var.tags":   {ShortName: "tags", FullName: "var.tags", Value: cty.StringVal("owner")},
+	}
+	variables := map[string]*modconfig.Variable{
+		"var.region": {ShortName: "region", FullName: "var.region", Value: cty.StringVal("us-east-2")},
+		"var.tags":   {ShortName: "tags", FullName: "var.tags", Value: cty.StringVal("owner")},
+	}
+	prevControls := map[string]*modconfig.Control{
+		"control.unchanged":     {ShortName: "unchanged", FullName: "control.unchanged", SQL: utils.ToStringPointer("select 1")},
+		"control.changed_sql":   {ShortName: "changed_sql", FullName: "control.changed_sql", SQL: utils.ToStringPointer("select 1")},
+		"control.uses_region":   {ShortName: "uses_region", FullName: "control.uses_region", SQL: utils.ToStringPointer("select 1")},
+		"control.uses_tags":     {ShortName: "uses_tags", FullName: "control.uses_tags", SQL: utils.ToStringPointer("select 1")},
+		"control.query_changed": {ShortName: "query_changed", FullName: "control.query_changed", Query: &modconfig.Query{FullName: "query.q1", SQL: utils.ToStringPointer("select 1")}},
+		"control.deleted":       {ShortName: "deleted", FullName: "control.deleted", SQL: utils.ToStringPointer("select 1")},
+	}
+	controls := map[string]*modconfig.Control{
+		"control.unchanged":     {ShortName: "unchanged", FullName: "control.unchanged", SQL: utils.ToStringPointer("select 1")},
+		"control.changed_sql":   {ShortName: "changed_sql", FullName: "control.changed_sql", SQL: utils.ToStringPointer("select 2")},
+		"control.uses_region":   {ShortName: "uses_region", FullName: "control.uses_region", SQL: utils.ToStringPointer("select 1"), References: []*modconfig.ResourceReference{{To: "var.region"}}},
+		"control.uses_tags":     {ShortName: "uses_tags", FullName: "control.uses_tags", SQL: utils.ToStringPointer("select 1"), References: []*modconfig.ResourceReference{{To: "var.tags"}}},
+		"control.query_changed": {ShortName: "query_changed", FullName: "control.query_changed", Query: &modconfig.Query{FullName: "query.q1", SQL: utils.ToStringPointer("select 2")}},
+		"control.new":           {ShortName: "new", FullName: "control.new", SQL: utils.ToStringPointer("select 1")},
+	}
+	// give the unchanged controls the same references before and after
+	prevControls["control.uses_region"].References = controls["control.uses_region"].References
+	prevControls["control.uses_tags"].References = controls["control.uses_tags"].References
+
+	var event *reportevents.ControlsChanged
+	w := &Workspace{}
+	w.RegisterReportEventHandler(func(e reportevents.ReportEvent) {
+		event, _ = e.(*reportevents.ControlsChanged)
+	})
+	w.raiseControlsChangedEvent(controls, prevControls, variables, prevVariables)
+	if event == nil {
+		t.Fatalf("Test: 'controls changed'' FAILED : expected a ControlsChanged event")
+	}
+
+	expectedChanged := []string{"control.changed_sql", "control.query_changed", "control.uses_region"}
+	if changed := controlNames(event.ChangedControls); !reflect.DeepEqual(changed, expectedChanged) {
+		t.Errorf("Test: 'changed controls'' FAILED : expected %v, got %v", expectedChanged, changed)
+	}
+	if added := controlNames(event.NewControls); !reflect.DeepEqual(added, []string{"control.new"}) {
+		t.Errorf("Test: 'new controls'' FAILED : expected [control.new], got %v", added)
+	}
+	if deleted := controlNames(event.DeletedControls); !reflect.DeepEqual(deleted, []string{"control.deleted"}) {
+		t.Errorf("Test: 'deleted controls'' FAILED : expected [control.deleted], got %v", deleted)
+	}
+}
+
+func controlNames(controls []*modconfig.Control) []string {
+	var res []string
+	for _, c := range controls {
+		res = append(res, c.Name())
+	}
+	sort.Strings(res)
+	return res
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/go-version"
 	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/mod_installer"
 	"github.com/turbot/steampipe/ociinstaller"
 	"github.com/turbot/steampipe/plugin"
 	"github.com/turbot/steampipe/utils"
@@ -48,6 +49,23 @@ func (w *Workspace) CheckRequiredPluginsInstalled() error {
 	return nil
 }
 
+// verifyWorkspaceLock returns an error if the installed mod dependencies do not match the workspace lock file,
+// and shows a warning for any locked dependency whose installed files have been modified
+func (w *Workspace) verifyWorkspaceLock() error {
+	workspaceLock, err := mod_installer.LoadWorkspaceLock(w.Path)
+	if err != nil {
+		return err
+	}
+	warnings, err := workspaceLock.Validate()
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		utils.ShowWarning(warning)
+	}
+	return nil
+}
+
 func (w *Workspace) getRequiredPlugins() map[string]*version.Version {
 	if w.Mod.Requires != nil {
 		requiredPluginVersions := w.Mod.Requires.Plugins